@@ -0,0 +1,37 @@
+package admin
+
+import (
+	"fmt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type cmdAddToGroup struct {
+	cmdAdmin
+	Username string
+	Group    string
+}
+
+func (v *cmdAddToGroup) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	err = adminHandler.AddUserToGroup(v.Username, v.Group)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("User", v.Username, "successfully added to group", v.Group+".")
+	return nil
+}
+
+// AddToGroup sub-command.
+func AddToGroup(c *kingpin.CmdClause) {
+	v := new(cmdAddToGroup)
+
+	command := c.Command("add-to-group", "Adds a Cognito Userpool user to a group.").Action(v.run)
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	command.Flag("group", "The group name").Required().StringVar(&v.Group)
+	v.flags(command)
+}
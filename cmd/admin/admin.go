@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/cache"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+)
+
+// cmdAdmin holds the flags shared by all admin sub-commands.
+type cmdAdmin struct {
+	ConfigFile string
+	CacheDir   string
+	Region     string
+}
+
+// adminHandler creates an admin handler using the logged in users own AWS credentials.
+func (v *cmdAdmin) adminHandler() (*userpool.AdminHandler, error) {
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	if cognitoConfig.UserPoolID == "" {
+		return nil, errors.New("not found: user_pool_id")
+	}
+
+	tokenCache, credentialsCache, err := cache.New(&cognitoConfig, v.CacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	cognitoIdentity := cognitoidentity.New(sess)
+	tokensRefresher := userpool.NewTokensRefresher(&cognitoConfig, tokenCache, cognitoIdentityProvider)
+	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), oauth.NewCacheLock(v.CacheDir))
+	credentialsResolver := awscreds.NewCredentialsResolver(&cognitoConfig, credentialsCache, tokensResolver, cognitoIdentity, awscreds.NewCacheLock(v.CacheDir))
+
+	creds, err := credentialsResolver.GetAwsCredentials()
+	if err != nil {
+		return nil, errors.Wrap(err, "Login required")
+	}
+
+	adminConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.NewStaticCredentials(creds.AccessKey, creds.SecretAccessKey, creds.SessionToken))
+	adminSess, err := session.NewSession(adminConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return userpool.NewAdminHandler(&cognitoConfig, cognitoidentityprovider.New(adminSess)), nil
+}
+
+// flags adds the flags shared by all admin sub-commands.
+func (v *cmdAdmin) flags(command *kingpin.CmdClause) {
+	homeDir, _ := os.UserHomeDir()
+	cacheDir, _ := os.UserCacheDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"fmt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type cmdCreateUser struct {
+	cmdAdmin
+	Username          string
+	TemporaryPassword string
+	Attributes        map[string]string
+	SuppressMessage   bool
+}
+
+func (v *cmdCreateUser) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	user, err := adminHandler.CreateUser(v.Username, v.TemporaryPassword, v.Attributes, v.SuppressMessage)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("User", user.Username, "successfully created with status", user.Status+".")
+	return nil
+}
+
+// CreateUser sub-command.
+func CreateUser(c *kingpin.CmdClause) {
+	v := new(cmdCreateUser)
+
+	command := c.Command("create-user", "Creates a Cognito Userpool user.").Action(v.run)
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	command.Flag("temporary-password", "The temporary password. Cognito generates one if not given.").StringVar(&v.TemporaryPassword)
+	command.Flag("attribute", "A user attribute in the form name=value (repeatable)").StringMapVar(&v.Attributes)
+	command.Flag("suppress-message", "Don't send the welcome message to the user.").BoolVar(&v.SuppressMessage)
+	v.flags(command)
+}
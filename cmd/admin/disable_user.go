@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"fmt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type cmdDisableUser struct {
+	cmdAdmin
+	Username string
+}
+
+func (v *cmdDisableUser) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	err = adminHandler.DisableUser(v.Username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("User", v.Username, "successfully disabled.")
+	return nil
+}
+
+// DisableUser sub-command.
+func DisableUser(c *kingpin.CmdClause) {
+	v := new(cmdDisableUser)
+
+	command := c.Command("disable-user", "Disables a Cognito Userpool user.").Action(v.run)
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	v.flags(command)
+}
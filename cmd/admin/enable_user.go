@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"fmt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type cmdEnableUser struct {
+	cmdAdmin
+	Username string
+}
+
+func (v *cmdEnableUser) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	err = adminHandler.EnableUser(v.Username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("User", v.Username, "successfully enabled.")
+	return nil
+}
+
+// EnableUser sub-command.
+func EnableUser(c *kingpin.CmdClause) {
+	v := new(cmdEnableUser)
+
+	command := c.Command("enable-user", "Enables a Cognito Userpool user.").Action(v.run)
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	v.flags(command)
+}
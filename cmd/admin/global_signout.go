@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"fmt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type cmdGlobalSignout struct {
+	cmdAdmin
+	Username string
+}
+
+func (v *cmdGlobalSignout) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	err = adminHandler.GlobalSignOut(v.Username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("User", v.Username, "successfully signed out of all devices.")
+	return nil
+}
+
+// GlobalSignout sub-command.
+func GlobalSignout(c *kingpin.CmdClause) {
+	v := new(cmdGlobalSignout)
+
+	command := c.Command("global-signout", "Signs a Cognito Userpool user out of all devices.").Action(v.run)
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	v.flags(command)
+}
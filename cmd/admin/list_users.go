@@ -0,0 +1,49 @@
+package admin
+
+import (
+	"fmt"
+	"github.com/gosuri/uitable"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"strconv"
+)
+
+type cmdListUsers struct {
+	cmdAdmin
+	Filter string
+}
+
+func (v *cmdListUsers) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	users, err := adminHandler.ListUsers(v.Filter)
+	if err != nil {
+		return err
+	}
+
+	if len(users) == 0 {
+		fmt.Println("No users found.")
+		return nil
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.AddRow("USERNAME", "EMAIL", "STATUS", "ENABLED")
+	for _, user := range users {
+		table.AddRow(user.Username, user.Attributes["email"], user.Status, strconv.FormatBool(user.Enabled))
+	}
+	fmt.Println(table)
+
+	return nil
+}
+
+// ListUsers sub-command.
+func ListUsers(c *kingpin.CmdClause) {
+	v := new(cmdListUsers)
+
+	command := c.Command("list-users", "Lists the Cognito Userpool users.").Action(v.run)
+	command.Flag("filter", "A Cognito ListUsers filter, e.g. 'email ^= \"jsmith\"'").StringVar(&v.Filter)
+	v.flags(command)
+}
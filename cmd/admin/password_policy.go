@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"gopkg.in/yaml.v2"
+)
+
+type cmdPasswordPolicy struct {
+	cmdAdmin
+}
+
+func (v *cmdPasswordPolicy) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	policy, err := adminHandler.GetPasswordPolicy()
+	if err != nil {
+		return err
+	}
+
+	// Print in the config format, so users can validate passwords without credentials.
+	data, err := yaml.Marshal(map[string]config.PasswordPolicy{"password_policy": policy})
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal password policy")
+	}
+
+	fmt.Print(string(data))
+	return nil
+}
+
+// PasswordPolicy sub-command.
+func PasswordPolicy(c *kingpin.CmdClause) {
+	v := new(cmdPasswordPolicy)
+
+	command := c.Command("password-policy", "Prints the Cognito Userpool password policy as configuration.").Action(v.run)
+	v.flags(command)
+}
@@ -0,0 +1,35 @@
+package admin
+
+import (
+	"fmt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type cmdResetUserPassword struct {
+	cmdAdmin
+	Username string
+}
+
+func (v *cmdResetUserPassword) run(c *kingpin.ParseContext) error {
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	err = adminHandler.ResetUserPassword(v.Username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Password reset for", v.Username+". They have been sent a password reset code.")
+	return nil
+}
+
+// ResetUserPassword sub-command.
+func ResetUserPassword(c *kingpin.CmdClause) {
+	v := new(cmdResetUserPassword)
+
+	command := c.Command("reset-user-password", "Resets a Cognito Userpool users password.").Action(v.run)
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	v.flags(command)
+}
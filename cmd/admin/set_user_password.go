@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"fmt"
+	"github.com/skpr/cognito-auth/cmd/prompt"
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type cmdSetUserPassword struct {
+	cmdAdmin
+	Username  string
+	Password  prompt.PasswordSource
+	Permanent bool
+}
+
+func (v *cmdSetUserPassword) run(c *kingpin.ParseContext) error {
+	password, err := v.Password.Read("", true)
+	if err != nil {
+		return err
+	}
+
+	adminHandler, err := v.adminHandler()
+	if err != nil {
+		return err
+	}
+
+	err = adminHandler.SetUserPassword(v.Username, password, v.Permanent)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Password successfully set for", v.Username+".")
+	return nil
+}
+
+// SetUserPassword sub-command.
+func SetUserPassword(c *kingpin.CmdClause) {
+	v := new(cmdSetUserPassword)
+
+	command := c.Command("set-user-password", "Sets a Cognito Userpool users password.").Action(v.run)
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	command.Flag("password-stdin", "Read the new password from stdin.").BoolVar(&v.Password.Stdin)
+	command.Flag("password-file", "Read the new password from a file.").StringVar(&v.Password.File)
+	command.Flag("permanent", "Set a permanent password, instead of one the user must change on login.").BoolVar(&v.Permanent)
+	v.flags(command)
+}
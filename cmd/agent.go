@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/skpr/cognito-auth/pkg/agent"
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/cache"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+type cmdAgent struct {
+	ConfigFile string
+	CacheDir   string
+	Region     string
+	Socket     string
+	Interval   time.Duration
+}
+
+func (v *cmdAgent) run(c *kingpin.ParseContext) error {
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	tokenCache, awsCredsCache, err := cache.New(&cognitoConfig, v.CacheDir)
+	if err != nil {
+		return err
+	}
+
+	cognitoIdentity := cognitoidentity.New(sess)
+	tokensRefresher := newTokensRefresher(&cognitoConfig, tokenCache, sess)
+	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), oauth.NewCacheLock(v.CacheDir))
+	credentialsResolver := awscreds.NewCredentialsResolver(&cognitoConfig, awsCredsCache, tokensResolver, cognitoIdentity, awscreds.NewCacheLock(v.CacheDir))
+
+	listener, err := agent.Listen(v.Socket)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(v.Socket)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+		listener.Close()
+	}()
+
+	a := agent.New(tokensResolver, credentialsResolver, v.Interval, notify)
+	go a.Run(ctx)
+
+	log.Println("Agent listening on", v.Socket)
+	err = a.Serve(listener)
+	if ctx.Err() != nil {
+		log.Println("Agent stopped")
+		return nil
+	}
+	return err
+}
+
+// notify logs the message, and shows a desktop notification where supported.
+func notify(message string) {
+	log.Println(message)
+	switch runtime.GOOS {
+	case "darwin":
+		_ = exec.Command("osascript", "-e", fmt.Sprintf("display notification %q with title %q", message, "cognito-auth")).Run()
+	case "linux":
+		_ = exec.Command("notify-send", "cognito-auth", message).Run()
+	}
+}
+
+// Agent agent command.
+func Agent(app *kingpin.Application) {
+	v := new(cmdAgent)
+	command := app.Command("agent", "Runs an agent which keeps credentials fresh, and serves them over a unix socket.").Action(v.run)
+	homeDir, _ := os.UserHomeDir()
+	cacheDir, _ := os.UserCacheDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+	command.Flag("socket", "The unix socket to listen on.").Default(cacheDir + "/cognito-auth/agent.sock").Envar("COGNITO_AUTH_AGENT_SOCKET").StringVar(&v.Socket)
+	command.Flag("interval", "How often to check for expiry.").Default("1m").DurationVar(&v.Interval)
+}
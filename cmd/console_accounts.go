@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/skpr/cognito-auth/pkg/consolesignin"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+// pickAccount picks an alias from the accounts, fuzzy matching the filter.
+//
+// If the filter doesn't match a single alias, the matches are written to out to
+// pick from, as long as in is a terminal.
+func pickAccount(accounts map[string]string, filter string, in *os.File, out io.Writer) (string, error) {
+	if _, ok := accounts[filter]; ok {
+		return filter, nil
+	}
+	if len(accounts) == 0 {
+		return "", errors.New("not found: console_accounts")
+	}
+
+	reader := bufio.NewReader(in)
+	for {
+		matches := consolesignin.MatchAccounts(accounts, filter)
+		if len(matches) == 1 {
+			return matches[0], nil
+		}
+
+		if !terminal.IsTerminal(int(in.Fd())) {
+			return "", errors.Errorf("account %q matches %d of console_accounts", filter, len(matches))
+		}
+
+		if len(matches) == 0 {
+			fmt.Fprintf(out, "No accounts match %q\n", filter)
+			matches = consolesignin.MatchAccounts(accounts, "")
+		}
+		for i, alias := range matches {
+			fmt.Fprintf(out, "%3d) %s\t%s\n", i+1, alias, accounts[alias])
+		}
+		fmt.Fprint(out, "Account (number or filter): ")
+
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", errors.Wrap(err, "Failed to read account")
+		}
+		line = strings.TrimSpace(line)
+
+		if n, err := strconv.Atoi(line); err == nil && n >= 1 && n <= len(matches) {
+			return matches[n-1], nil
+		}
+		if _, ok := accounts[line]; ok {
+			return line, nil
+		}
+		filter = line
+	}
+}
+
+// roleSessionName names the role session after the logged in user, so it shows in CloudTrail.
+func roleSessionName(tokensResolver *oauth.TokensResolver) string {
+	tokens, err := tokensResolver.GetTokens()
+	if err != nil {
+		return consolesignin.SessionName("")
+	}
+	claims, err := oauth.ParseClaims(tokens.IDToken)
+	if err != nil {
+		return consolesignin.SessionName("")
+	}
+	if claims.Username != "" {
+		return consolesignin.SessionName(claims.Username)
+	}
+	return consolesignin.SessionName(claims.Email)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPickAccount(t *testing.T) {
+	accounts := map[string]string{
+		"production": "arn:aws:iam::111111111111:role/admin",
+		"staging":    "arn:aws:iam::222222222222:role/admin",
+	}
+
+	// A file isn't a terminal, so the accounts can't be picked from interactively.
+	in, err := ioutil.TempFile("", "stdin")
+	assert.Nil(t, err)
+	defer os.Remove(in.Name())
+	defer in.Close()
+
+	tests := []struct {
+		name        string
+		accounts    map[string]string
+		filter      string
+		expected    string
+		expectedErr string
+	}{
+		{name: "exact", accounts: accounts, filter: "staging", expected: "staging"},
+		{name: "fuzzy", accounts: accounts, filter: "prd", expected: "production"},
+		{name: "ambiguous", accounts: accounts, filter: "in", expectedErr: `account "in" matches 2 of console_accounts`},
+		{name: "no match", accounts: accounts, filter: "dev", expectedErr: `account "dev" matches 0 of console_accounts`},
+		{name: "no accounts", filter: "dev", expectedErr: "not found: console_accounts"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			alias, err := pickAccount(test.accounts, test.filter, in, ioutil.Discard)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, alias)
+		})
+	}
+}
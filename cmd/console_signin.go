@@ -6,23 +6,30 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cognitoidentity"
-	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
 	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/cache"
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/consolesignin"
 	"github.com/skpr/cognito-auth/pkg/oauth"
-	"github.com/skpr/cognito-auth/pkg/secrets"
-	"github.com/skpr/cognito-auth/pkg/userpool"
 	"github.com/skratchdot/open-golang/open"
 	"gopkg.in/alecthomas/kingpin.v2"
+	"net/http"
 	"os"
-	"os/user"
+	"time"
 )
 
 type cmdConsoleSignIn struct {
-	ConfigFile string
-	CacheDir   string
-	Region     string
+	ConfigFile  string
+	CacheDir    string
+	Region      string
+	Destination string
+	Service     string
+	Duration    time.Duration
+	PrintOnly   bool
+	Browser     string
+	Account     string
+	PickAccount bool
 }
 
 func (v *cmdConsoleSignIn) run(c *kingpin.ParseContext) error {
@@ -37,38 +44,61 @@ func (v *cmdConsoleSignIn) run(c *kingpin.ParseContext) error {
 		return err
 	}
 
-	var tokenCache oauth.TokenCache
-	var awsCredsCache awscreds.CredentialsCache
-
-	if cognitoConfig.CredsStore == "native" {
-		currentUser, err := user.Current()
+	destination := v.Destination
+	if v.Service != "" {
+		if destination != "" {
+			return errors.New("--destination and --service cannot be used together")
+		}
+		destination, err = cognitoConfig.ConsoleServiceDestination(v.Service)
 		if err != nil {
 			return err
 		}
-		tokenKeychain := secrets.NewKeychain(cognitoConfig.CredsOAuthKey, currentUser.Username)
-		tokenCache = oauth.NewKeychainCache(tokenKeychain)
-		awsCredsKeychain := secrets.NewKeychain(cognitoConfig.CredsAwsKey, currentUser.Username)
-		awsCredsCache = awscreds.NewKeychainCache(awsCredsKeychain)
-	} else {
-		tokenCache = oauth.NewFileCache(v.CacheDir)
-		awsCredsCache = awscreds.NewFileCache(v.CacheDir)
 	}
 
-	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	if v.Duration != 0 && v.Account == "" && !v.PickAccount {
+		return errors.New("--duration can only be used with --account or --pick-account")
+	}
+
+	tokenCache, awsCredsCache, err := cache.New(&cognitoConfig, v.CacheDir)
+	if err != nil {
+		return err
+	}
+
 	cognitoIdentity := cognitoidentity.New(sess)
-	tokensRefresher := userpool.NewTokensRefresher(&cognitoConfig, tokenCache, cognitoIdentityProvider)
-	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher)
+	tokensRefresher := newTokensRefresher(&cognitoConfig, tokenCache, sess)
+	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), oauth.NewCacheLock(v.CacheDir))
+
+	credentialsResolver := awscreds.NewCredentialsResolver(&cognitoConfig, awsCredsCache, tokensResolver, cognitoIdentity, awscreds.NewCacheLock(v.CacheDir))
+	var credentialsGetter consolesignin.CredentialsGetter = credentialsResolver
+	if v.Account != "" || v.PickAccount {
+		alias, err := pickAccount(cognitoConfig.ConsoleAccounts, v.Account, os.Stdin, os.Stderr)
+		if err != nil {
+			return err
+		}
+		credentialsGetter = consolesignin.NewRoleCredentials(credentialsResolver, consolesignin.NewSTSFactory(sess), cognitoConfig.ConsoleAccounts[alias], roleSessionName(tokensResolver), v.Duration)
+	}
 
-	credentialsResolver := awscreds.NewCredentialsResolver(&cognitoConfig, awsCredsCache, tokensResolver, cognitoIdentity)
-	signin := consolesignin.New(&cognitoConfig, credentialsResolver)
+	signin := consolesignin.New(&cognitoConfig, credentialsGetter, &http.Client{Timeout: 30 * time.Second})
 
-	link, err := signin.GetSignInLink()
+	link, err := signin.GetSignInLink(destination)
 	if err != nil {
-		fmt.Println("Login required")
+		if oauth.IsLoginRequired(err) {
+			fmt.Fprintln(os.Stderr, "Login required")
+		}
 		return err
 	}
+
+	if v.PrintOnly {
+		fmt.Println(link)
+		return nil
+	}
+
 	fmt.Println("You will now be taken to your browser to login to the AWS console.")
-	err = open.Run(link)
+	if v.Browser != "" {
+		err = open.RunWith(link, v.Browser)
+	} else {
+		err = open.Run(link)
+	}
 	if err != nil {
 		return err
 	}
@@ -86,4 +116,11 @@ func ConsoleSignIn(app *kingpin.Application) {
 	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/oidc.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
 	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
 	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+	command.Flag("destination", "The console URL to sign in to, instead of console_destination.").StringVar(&v.Destination)
+	command.Flag("service", "The console_services shortcut to sign in to.").StringVar(&v.Service)
+	command.Flag("duration", "How long the assumed --account role session lasts, between 15m and 12h. Defaults to the role's default session duration.").Envar("COGNITO_AUTH_CONSOLE_DURATION").DurationVar(&v.Duration)
+	command.Flag("print-only", "Print the sign-in link instead of opening a browser.").BoolVar(&v.PrintOnly)
+	command.Flag("account", "The console_accounts alias to sign in to, fuzzy matched.").Envar("COGNITO_AUTH_ACCOUNT").StringVar(&v.Account)
+	command.Flag("pick-account", "Pick the account to sign in to from console_accounts.").BoolVar(&v.PickAccount)
+	command.Flag("browser", "The browser to open the sign-in link with.").Envar("COGNITO_AUTH_BROWSER").StringVar(&v.Browser)
 }
@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"fmt"
+)
+
+// ExitError is returned by commands which exit with a code, rather than an error message.
+//
+// It is mapped to the exit code in main, so deferred cleanup in the command still runs.
+type ExitError struct {
+	Code int
+}
+
+// Error returns the exit code as the message.
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
@@ -3,7 +3,8 @@ package oidc
 import (
 	"fmt"
 	"os"
-	"os/user"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -15,12 +16,14 @@ import (
 
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/oidc"
+	"github.com/skpr/cognito-auth/pkg/output"
 )
 
 type cmdLogin struct {
 	ConfigFile string
 	CacheDir   string
 	Region     string
+	Output     string
 }
 
 func (v *cmdLogin) run(c *kingpin.ParseContext) error {
@@ -36,26 +39,26 @@ func (v *cmdLogin) run(c *kingpin.ParseContext) error {
 		return err
 	}
 
-	var handler *oidc.LoginHandler
-	if cognitoConfig.CredsStore == "native" {
-		currentUser, err := user.Current()
-		if err != nil {
-			return err
-		}
-		handler = oidc.CreateLoginHandlerKeychainCache(&cognitoConfig, sess, currentUser.Username)
-	} else {
-		handler = oidc.CreateLoginHandlerFileCache(&cognitoConfig, sess, v.CacheDir)
+	handler, err := oidc.CreateLoginHandlerConfiguredCache(&cognitoConfig, sess, v.CacheDir)
+	if err != nil {
+		return err
 	}
 
 	authURL, state := handler.GetAuthCodeURL()
 
-	fmt.Println("You will now be taken to your browser to login.")
+	// Keep stdout for the result, so it can be parsed by scripts.
+	info := os.Stdout
+	if v.Output != output.FormatText {
+		info = os.Stderr
+	}
+
+	fmt.Fprintln(info, "You will now be taken to your browser to login.")
 	time.Sleep(1 * time.Second)
 	err = open.Run(authURL)
 	if err != nil {
 		return err
 	}
-	fmt.Println("Authentication URL:", authURL)
+	fmt.Fprintln(info, "Authentication URL:", authURL)
 
 	creds, err := handler.Handle(state)
 
@@ -63,9 +66,16 @@ func (v *cmdLogin) run(c *kingpin.ParseContext) error {
 		return errors.Wrap(err, "Failed to login")
 	}
 
-	fmt.Println(creds)
+	tokens, err := handler.Tokens()
+	if err != nil {
+		return err
+	}
 
-	return nil
+	profile := strings.TrimSuffix(filepath.Base(v.ConfigFile), filepath.Ext(v.ConfigFile))
+	if v.Output == output.FormatText {
+		fmt.Println("You successfully logged in.")
+	}
+	return output.NewLoginResult(profile, tokens, creds).Print(os.Stdout, v.Output)
 }
 
 // Login sub-command.
@@ -87,4 +97,8 @@ func Login(c *kingpin.CmdClause) {
 		Default("ap-southeast-2").
 		Envar("COGNITO_AUTH_REGION").
 		StringVar(&v.Region)
+	command.Flag("output", "The output format").
+		Short('o').
+		Default(output.FormatText).
+		EnumVar(&v.Output, output.Formats...)
 }
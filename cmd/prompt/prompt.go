@@ -0,0 +1,162 @@
+// Package prompt reads input and passwords for commands.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh/terminal"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// stdin is shared so that buffered input isn't lost between prompts.
+var stdin = bufio.NewReader(os.Stdin)
+
+// ReadLine prompts for a line of input.
+//
+// Prompts are written to stderr, so they don't mix with output for scripts.
+func ReadLine(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	text, err := stdin.ReadString('\n')
+	if err != nil && !(err == io.EOF && text != "") {
+		return "", errors.Wrap(err, "Failed to read input")
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// ReadConfirmation prompts for a yes or no answer.
+func ReadConfirmation(prompt string) (bool, error) {
+	text, err := ReadLine(prompt + " [y/n] ")
+	if err != nil {
+		return false, err
+	}
+	return strings.ContainsAny(text, "yY"), nil
+}
+
+// ReadPassword prompts for a password without echoing it to the terminal.
+func ReadPassword(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	bytecode, err := terminal.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to read password")
+	}
+	return strings.TrimSpace(string(bytecode)), nil
+}
+
+// ReadPasswordStdin reads a password from the first line of stdin, for use in scripts.
+func ReadPasswordStdin() (string, error) {
+	text, err := stdin.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", errors.Wrap(err, "Failed to read password from stdin")
+	}
+	return firstLine(text)
+}
+
+// ReadPasswordFile reads a password from the first line of a file.
+func ReadPasswordFile(file string) (string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to read password file")
+	}
+	return firstLine(string(data))
+}
+
+// ReadPasswordCommand reads a password from the first line of a commands output,
+// e.g. a password manager such as `pass show skpr`.
+func ReadPasswordCommand(command string) (string, error) {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to run password command")
+	}
+	return firstLine(string(output))
+}
+
+// firstLine returns the first line of the text as a password.
+func firstLine(text string) (string, error) {
+	password := strings.TrimRight(strings.SplitN(text, "\n", 2)[0], "\r")
+	if password == "" {
+		return "", errors.New("Password is required")
+	}
+	return password, nil
+}
+
+// ReadNewPassword prompts for a new password and its confirmation.
+func ReadNewPassword() (string, error) {
+	password, err := ReadPassword("Enter the new password: ")
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", errors.New("Password is required")
+	}
+
+	confirmedPassword, err := ReadPassword("Confirm the new password: ")
+	if err != nil {
+		return "", err
+	}
+
+	if password != confirmedPassword {
+		return "", errors.New("Passwords do not match! Please try again.")
+	}
+
+	return password, nil
+}
+
+// PasswordSource reads a password from the configured source, for commands
+// with --password-stdin, --password-file or --password-command flags.
+//
+// Password is only for commands which still accept the password as a flag,
+// which is visible in shell history and the process list.
+type PasswordSource struct {
+	Password string
+	Stdin    bool
+	File     string
+	Command  string
+}
+
+// Read reads the password from the configured source.
+//
+// If no source is configured, the password is prompted for, with a
+// confirmation if it is a new password.
+func (s *PasswordSource) Read(prompt string, newPassword bool) (string, error) {
+	sources := 0
+	for _, set := range []bool{s.Password != "", s.Stdin, s.File != "", s.Command != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return "", errors.New("Only one of the password options can be used")
+	}
+
+	switch {
+	case s.Password != "":
+		return s.Password, nil
+	case s.Stdin:
+		return ReadPasswordStdin()
+	case s.File != "":
+		return ReadPasswordFile(s.File)
+	case s.Command != "":
+		return ReadPasswordCommand(s.Command)
+	case newPassword:
+		return ReadNewPassword()
+	}
+
+	password, err := ReadPassword(prompt)
+	if err != nil {
+		return "", err
+	}
+	if password == "" {
+		return "", errors.New("Password is required")
+	}
+	return password, nil
+}
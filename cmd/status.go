@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/skpr/cognito-auth/pkg/cache"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/output"
+	"github.com/skpr/cognito-auth/pkg/secrets"
+	"github.com/skpr/cognito-auth/pkg/status"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+)
+
+type cmdStatus struct {
+	ConfigFile string
+	CacheDir   string
+	Output     string
+}
+
+func (v *cmdStatus) run(c *kingpin.ParseContext) error {
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	tokenCache, awsCredsCache, err := cache.New(&cognitoConfig, v.CacheDir)
+	if err != nil {
+		return err
+	}
+
+	cacheBackend := cognitoConfig.CredsStore
+	if cacheBackend == "" || cacheBackend == secrets.StoreFile {
+		cacheBackend = "file (" + v.CacheDir + ")"
+	}
+
+	s := status.Check(cacheBackend, tokenCache, awsCredsCache)
+	err = s.Print(os.Stdout, v.Output)
+	if err != nil {
+		return err
+	}
+
+	// The exit code is part of the output, so scripts can check it without parsing.
+	if code := s.ExitCode(); code != status.ExitLoggedIn {
+		return &ExitError{Code: code}
+	}
+
+	return nil
+}
+
+// Status status command.
+func Status(app *kingpin.Application) {
+	v := new(cmdStatus)
+	command := app.Command("status", "Shows the status of the cached session, without refreshing it.").Action(v.run)
+	homeDir, _ := os.UserHomeDir()
+	cacheDir, _ := os.UserCacheDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/oidc.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
+	command.Flag("output", "The output format").Short('o').Default(output.FormatText).EnumVar(&v.Output, output.Formats...)
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+	"github.com/skpr/cognito-auth/pkg/oidc"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+)
+
+// newTokensRefresher creates the refresher for the session type recorded with the cached tokens.
+func newTokensRefresher(cognitoConfig *config.Config, tokenCache oauth.TokenCache, sess client.ConfigProvider) oauth.TokensRefresher {
+	// Without cached tokens, the session type falls back to the config.
+	tokens, _ := tokenCache.Get()
+
+	if oauth.SessionType(tokens, cognitoConfig) == oauth.SessionOIDC {
+		return oidc.NewTokensRefresher(cognitoConfig, tokenCache)
+	}
+	return userpool.NewTokensRefresher(cognitoConfig, tokenCache, cognitoidentityprovider.New(sess))
+}
@@ -0,0 +1,154 @@
+package userpool
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/gosuri/uitable"
+	"github.com/skpr/cognito-auth/cmd/prompt"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+	"sort"
+)
+
+type cmdAttributes struct {
+	Names      []string
+	Attributes map[string]string
+	Name       string
+	Code       string
+	ConfigFile string
+	CacheDir   string
+	Region     string
+}
+
+// profileHandler creates a profile handler for the logged in user.
+func (v *cmdAttributes) profileHandler() (*userpool.ProfileHandler, error) {
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	tokensResolver, err := newTokensResolver(&cognitoConfig, v.CacheDir, cognitoIdentityProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return userpool.NewProfileHandler(tokensResolver, cognitoIdentityProvider), nil
+}
+
+func (v *cmdAttributes) get(c *kingpin.ParseContext) error {
+	profileHandler, err := v.profileHandler()
+	if err != nil {
+		return err
+	}
+
+	user, err := profileHandler.GetUser()
+	if err != nil {
+		return err
+	}
+
+	names := v.Names
+	if len(names) == 0 {
+		for name := range user.Attributes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	for _, name := range names {
+		table.AddRow(name+":", user.Attributes[name])
+	}
+	fmt.Println(table)
+
+	return nil
+}
+
+func (v *cmdAttributes) set(c *kingpin.ParseContext) error {
+	profileHandler, err := v.profileHandler()
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := profileHandler.UpdateAttributes(v.Attributes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Attributes successfully updated.")
+	for name, delivery := range deliveries {
+		fmt.Println("A verification code for", name, "has been sent to", delivery.Destination, "("+delivery.DeliveryMedium+").")
+		fmt.Println("Run 'userpool attributes verify --name", name+"' to verify it.")
+	}
+
+	return nil
+}
+
+func (v *cmdAttributes) verify(c *kingpin.ParseContext) error {
+	profileHandler, err := v.profileHandler()
+	if err != nil {
+		return err
+	}
+
+	code := v.Code
+	if code == "" {
+		delivery, err := profileHandler.GetAttributeVerificationCode(v.Name)
+		if err != nil {
+			return err
+		}
+		fmt.Println("A verification code has been sent to", delivery.Destination, "("+delivery.DeliveryMedium+").")
+		code, err = prompt.ReadLine("Enter the verification code: ")
+		if err != nil {
+			return err
+		}
+	}
+
+	err = profileHandler.VerifyAttribute(v.Name, code)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Attribute", v.Name, "successfully verified.")
+	return nil
+}
+
+// flags adds the flags shared by all attributes sub-commands.
+func (v *cmdAttributes) flags(command *kingpin.CmdClause) {
+	homeDir, _ := os.UserHomeDir()
+	cacheDir, _ := os.UserCacheDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
+
+// Attributes sub-commands.
+func Attributes(c *kingpin.CmdClause) {
+	v := new(cmdAttributes)
+
+	command := c.Command("attributes", "Manages the logged in users Cognito Userpool attributes.")
+
+	get := command.Command("get", "Shows the users attributes.").Action(v.get)
+	get.Arg("names", "The attributes to show. Shows all attributes if none are given.").StringsVar(&v.Names)
+	v.flags(get)
+
+	set := command.Command("set", "Updates the users attributes.").Action(v.set)
+	set.Flag("attribute", "A user attribute in the form name=value (repeatable)").Required().StringMapVar(&v.Attributes)
+	v.flags(set)
+
+	verify := command.Command("verify", "Verifies a users attribute, such as email or phone_number.").Action(v.verify)
+	verify.Flag("name", "The attribute name").Required().StringVar(&v.Name)
+	verify.Flag("code", "The verification code. A new code is sent if not given.").StringVar(&v.Code)
+	v.flags(verify)
+}
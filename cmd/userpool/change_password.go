@@ -0,0 +1,75 @@
+package userpool
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/cmd/prompt"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+)
+
+type cmdChangePassword struct {
+	ConfigFile string
+	CacheDir   string
+	Region     string
+}
+
+func (v *cmdChangePassword) run(c *kingpin.ParseContext) error {
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	tokensResolver, err := newTokensResolver(&cognitoConfig, v.CacheDir, cognitoIdentityProvider)
+	if err != nil {
+		return err
+	}
+
+	passwordChanger := userpool.NewPasswordChanger(tokensResolver, cognitoIdentityProvider)
+
+	previousPassword, err := prompt.ReadPassword("Enter the current password: ")
+	if err != nil {
+		return err
+	}
+	if previousPassword == "" {
+		return errors.New("Password is required")
+	}
+
+	proposedPassword, err := prompt.ReadNewPassword()
+	if err != nil {
+		return err
+	}
+
+	err = passwordChanger.ChangePassword(previousPassword, proposedPassword)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Password successfully updated.")
+	return nil
+}
+
+// ChangePassword sub-command.
+func ChangePassword(c *kingpin.CmdClause) {
+	v := new(cmdChangePassword)
+
+	command := c.Command("change-password", "Changes the logged in users Cognito Userpool password.").Action(v.run)
+	homeDir, _ := os.UserHomeDir()
+	cacheDir, _ := os.UserCacheDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
@@ -0,0 +1,68 @@
+package userpool
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/skpr/cognito-auth/cmd/prompt"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+)
+
+type cmdConfirmSignup struct {
+	Username   string
+	Code       string
+	ConfigFile string
+	Region     string
+}
+
+func (v *cmdConfirmSignup) run(c *kingpin.ParseContext) error {
+
+	code := v.Code
+	if code == "" {
+		var err error
+		code, err = prompt.ReadLine("Enter the confirmation code: ")
+		if err != nil {
+			return err
+		}
+	}
+
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	signupHandler := userpool.NewSignupHandler(&cognitoConfig, cognitoIdentityProvider)
+
+	err = signupHandler.ConfirmSignUp(v.Username, code)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Your account has been confirmed. You can now log in.")
+	return nil
+}
+
+// ConfirmSignup sub-command.
+func ConfirmSignup(c *kingpin.CmdClause) {
+	v := new(cmdConfirmSignup)
+
+	command := c.Command("confirm-signup", "Confirms a new Cognito Userpool user with their confirmation code.").Action(v.run)
+
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	command.Flag("code", "The confirmation code").StringVar(&v.Code)
+	homeDir, _ := os.UserHomeDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
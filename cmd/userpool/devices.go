@@ -0,0 +1,134 @@
+package userpool
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/gosuri/uitable"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+	"time"
+)
+
+type cmdDevices struct {
+	DeviceKey     string
+	NotRemembered bool
+	ConfigFile    string
+	CacheDir      string
+	Region        string
+}
+
+// deviceHandler creates a device handler for the logged in user.
+func (v *cmdDevices) deviceHandler() (*userpool.DeviceHandler, error) {
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	tokensResolver, err := newTokensResolver(&cognitoConfig, v.CacheDir, cognitoIdentityProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	return userpool.NewDeviceHandler(tokensResolver, cognitoIdentityProvider), nil
+}
+
+func (v *cmdDevices) list(c *kingpin.ParseContext) error {
+	deviceHandler, err := v.deviceHandler()
+	if err != nil {
+		return err
+	}
+
+	devices, err := deviceHandler.ListDevices()
+	if err != nil {
+		return err
+	}
+
+	if len(devices) == 0 {
+		fmt.Println("No devices found.")
+		return nil
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.AddRow("KEY", "NAME", "LAST IP", "STATUS", "LAST AUTHENTICATED")
+	for _, device := range devices {
+		table.AddRow(device.Key, device.Name, device.LastIP, device.RememberedStatus, device.LastAuthenticated.Local().Format(time.RFC822))
+	}
+	fmt.Println(table)
+
+	return nil
+}
+
+func (v *cmdDevices) forget(c *kingpin.ParseContext) error {
+	deviceHandler, err := v.deviceHandler()
+	if err != nil {
+		return err
+	}
+
+	err = deviceHandler.ForgetDevice(v.DeviceKey)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Device", v.DeviceKey, "successfully forgotten.")
+	return nil
+}
+
+func (v *cmdDevices) remember(c *kingpin.ParseContext) error {
+	deviceHandler, err := v.deviceHandler()
+	if err != nil {
+		return err
+	}
+
+	err = deviceHandler.RememberDevice(v.DeviceKey, !v.NotRemembered)
+	if err != nil {
+		return err
+	}
+
+	if v.NotRemembered {
+		fmt.Println("Device", v.DeviceKey, "is no longer remembered.")
+	} else {
+		fmt.Println("Device", v.DeviceKey, "is now remembered.")
+	}
+	return nil
+}
+
+// flags adds the flags shared by all devices sub-commands.
+func (v *cmdDevices) flags(command *kingpin.CmdClause) {
+	homeDir, _ := os.UserHomeDir()
+	cacheDir, _ := os.UserCacheDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
+
+// Devices sub-commands.
+func Devices(c *kingpin.CmdClause) {
+	v := new(cmdDevices)
+
+	command := c.Command("devices", "Manages the logged in users Cognito Userpool devices.")
+
+	list := command.Command("list", "Lists the users devices.").Action(v.list)
+	v.flags(list)
+
+	forget := command.Command("forget", "Forgets a device, so it is no longer trusted.").Action(v.forget)
+	forget.Arg("device-key", "The device key").Required().StringVar(&v.DeviceKey)
+	v.flags(forget)
+
+	remember := command.Command("remember", "Updates whether a device is remembered.").Action(v.remember)
+	remember.Arg("device-key", "The device key").Required().StringVar(&v.DeviceKey)
+	remember.Flag("not-remembered", "Stop remembering the device instead.").BoolVar(&v.NotRemembered)
+	v.flags(remember)
+}
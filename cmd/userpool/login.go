@@ -7,44 +7,33 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cognitoidentity"
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
-	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/cmd/prompt"
 	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/cache"
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/oauth"
-	"github.com/skpr/cognito-auth/pkg/secrets"
+	"github.com/skpr/cognito-auth/pkg/output"
 	"github.com/skpr/cognito-auth/pkg/userpool"
-	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/alecthomas/kingpin.v2"
 	"os"
-	"os/user"
+	"path/filepath"
 	"strings"
-	"syscall"
 )
 
 type cmdLogin struct {
 	Username   string
-	Password   string
+	Password   prompt.PasswordSource
 	ConfigFile string
 	CacheDir   string
 	Region     string
+	Output     string
 }
 
 func (v *cmdLogin) run(c *kingpin.ParseContext) error {
 
-	password := v.Password
-	if password == "" {
-		fmt.Print("Password: ")
-		bytecode, err := terminal.ReadPassword(int(syscall.Stdin))
-		if err != nil {
-			fmt.Println("Failed to read password")
-			return err
-		}
-		password = string(bytecode)
-		password = strings.TrimSpace(password)
-		if password == "" {
-			return errors.New("Password is required")
-		}
-		fmt.Println()
+	password, err := v.Password.Read("Password: ", false)
+	if err != nil {
+		return err
 	}
 
 	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
@@ -58,28 +47,16 @@ func (v *cmdLogin) run(c *kingpin.ParseContext) error {
 		return err
 	}
 
-	var tokenCache oauth.TokenCache
-	var credentialsCache awscreds.CredentialsCache
-
-	if cognitoConfig.CredsStore == "native" {
-		currentUser, err := user.Current()
-		if err != nil {
-			return err
-		}
-		oauth2Keychain := secrets.NewKeychain(cognitoConfig.CredsOAuthKey, currentUser.Username)
-		tokenCache = oauth.NewKeychainCache(oauth2Keychain)
-		awsCredsKeychain := secrets.NewKeychain(cognitoConfig.CredsAwsKey, currentUser.Username)
-		credentialsCache = awscreds.NewKeychainCache(awsCredsKeychain)
-	} else {
-		tokenCache = oauth.NewFileCache(v.CacheDir)
-		credentialsCache = awscreds.NewFileCache(v.CacheDir)
+	tokenCache, credentialsCache, err := cache.New(&cognitoConfig, v.CacheDir)
+	if err != nil {
+		return err
 	}
 
 	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
 	cognitoIdentity := cognitoidentity.New(sess)
 	tokensRefresher := userpool.NewTokensRefresher(&cognitoConfig, tokenCache, cognitoIdentityProvider)
-	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher)
-	credentialsResolver := awscreds.NewCredentialsResolver(&cognitoConfig, credentialsCache, tokensResolver, cognitoIdentity)
+	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), oauth.NewCacheLock(v.CacheDir))
+	credentialsResolver := awscreds.NewCredentialsResolver(&cognitoConfig, credentialsCache, tokensResolver, cognitoIdentity, awscreds.NewCacheLock(v.CacheDir))
 
 	loginHandler := userpool.NewLoginHandler(tokenCache, &cognitoConfig, cognitoIdentityProvider, credentialsResolver)
 
@@ -89,47 +66,31 @@ func (v *cmdLogin) run(c *kingpin.ParseContext) error {
 	}
 
 	if challenge.Name == "NEW_PASSWORD_REQUIRED" {
-		fmt.Println("You are required to change your password.")
-		fmt.Print("Enter the new password: ")
-		bytecode, err := terminal.ReadPassword(int(syscall.Stdin))
+		fmt.Fprintln(os.Stderr, "You are required to change your password.")
+		newPassword, err := prompt.ReadNewPassword()
 		if err != nil {
-			fmt.Println("Failed to read password")
-			return err
-		}
-		newPassword := string(bytecode)
-		newPassword = strings.TrimSpace(newPassword)
-
-		fmt.Println()
-		fmt.Print("Confirm the new password: ")
-		bytecode, err = terminal.ReadPassword(int(syscall.Stdin))
-		if err != nil {
-			fmt.Println()
-			fmt.Println("Failed to read password confirmation")
-			return err
-		}
-		confirmedPassword := string(bytecode)
-		confirmedPassword = strings.TrimSpace(confirmedPassword)
-
-		if newPassword != confirmedPassword {
-			fmt.Println()
-			fmt.Println("Passwords do not match! Please try again.")
 			return err
 		}
 
 		creds, err = loginHandler.ChangePasswordChallenge(v.Username, newPassword, challenge.Session)
 		if err != nil {
-			fmt.Println(err)
 			return err
 		}
 
-		fmt.Println()
-		fmt.Println("Successfully changed password.")
+		fmt.Fprintln(os.Stderr, "Successfully changed password.")
+
+	}
 
+	tokens, err := tokenCache.Get()
+	if err != nil {
+		return err
 	}
 
-	fmt.Println(creds)
-	fmt.Println("You successfully logged in.")
-	return nil
+	profile := strings.TrimSuffix(filepath.Base(v.ConfigFile), filepath.Ext(v.ConfigFile))
+	if v.Output == output.FormatText {
+		fmt.Println("You successfully logged in.")
+	}
+	return output.NewLoginResult(profile, tokens, creds).Print(os.Stdout, v.Output)
 }
 
 // Login sub-command.
@@ -139,10 +100,14 @@ func Login(c *kingpin.CmdClause) {
 	command := c.Command("login", "Logs in a user to a Cognito Userpool.").Action(v.run)
 
 	command.Flag("username", "Username for authentication").Required().StringVar(&v.Username)
-	command.Flag("password", "Password for authentication").StringVar(&v.Password)
+	command.Flag("password", "Password for authentication. Prefer one of the other password options, as this is visible in the process list.").StringVar(&v.Password.Password)
+	command.Flag("password-stdin", "Read the password from stdin").BoolVar(&v.Password.Stdin)
+	command.Flag("password-file", "Read the password from a file").Envar("COGNITO_AUTH_PASSWORD_FILE").StringVar(&v.Password.File)
+	command.Flag("password-command", "Read the password from the output of a command, e.g. 'pass show skpr'").Envar("COGNITO_AUTH_PASSWORD_COMMAND").StringVar(&v.Password.Command)
 	homeDir, _ := os.UserHomeDir()
 	cacheDir, _ := os.UserCacheDir()
 	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
 	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
 	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+	command.Flag("output", "The output format").Short('o').Default(output.FormatText).EnumVar(&v.Output, output.Formats...)
 }
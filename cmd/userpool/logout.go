@@ -3,10 +3,8 @@ package userpool
 import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws/credentials"
-	"github.com/skpr/cognito-auth/pkg/awscreds"
-	"github.com/skpr/cognito-auth/pkg/secrets"
+	"github.com/skpr/cognito-auth/pkg/cache"
 	"os"
-	"os/user"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -37,26 +35,14 @@ func (v *cmdLogout) run(c *kingpin.ParseContext) error {
 		return err
 	}
 
-	var tokenCache oauth.TokenCache
-	var credentialsCache awscreds.CredentialsCache
-
-	if cognitoConfig.CredsStore == "native" {
-		currentUser, err := user.Current()
-		if err != nil {
-			return err
-		}
-		oauth2Keychain := secrets.NewKeychain(cognitoConfig.CredsOAuthKey, currentUser.Username)
-		tokenCache = oauth.NewKeychainCache(oauth2Keychain)
-		awsCredsKeychain := secrets.NewKeychain(cognitoConfig.CredsAwsKey, currentUser.Username)
-		credentialsCache = awscreds.NewKeychainCache(awsCredsKeychain)
-	} else {
-		tokenCache = oauth.NewFileCache(v.CacheDir)
-		credentialsCache = awscreds.NewFileCache(v.CacheDir)
+	tokenCache, credentialsCache, err := cache.New(&cognitoConfig, v.CacheDir)
+	if err != nil {
+		return err
 	}
 
 	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
 	tokensRefresher := userpool.NewTokensRefresher(&cognitoConfig, tokenCache, cognitoIdentityProvider)
-	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher)
+	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), oauth.NewCacheLock(v.CacheDir))
 
 	logoutHander := userpool.NewLogoutHandler(credentialsCache, tokenCache, tokensResolver, cognitoIdentityProvider)
 
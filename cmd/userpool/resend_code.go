@@ -0,0 +1,55 @@
+package userpool
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+)
+
+type cmdResendCode struct {
+	Username   string
+	ConfigFile string
+	Region     string
+}
+
+func (v *cmdResendCode) run(c *kingpin.ParseContext) error {
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	signupHandler := userpool.NewSignupHandler(&cognitoConfig, cognitoIdentityProvider)
+
+	delivery, err := signupHandler.ResendConfirmationCode(v.Username)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("A new confirmation code has been sent to", delivery.Destination, "("+delivery.DeliveryMedium+").")
+	return nil
+}
+
+// ResendCode sub-command.
+func ResendCode(c *kingpin.CmdClause) {
+	v := new(cmdResendCode)
+
+	command := c.Command("resend-code", "Resends the sign up confirmation code for a Cognito Userpool user.").Action(v.run)
+
+	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	homeDir, _ := os.UserHomeDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
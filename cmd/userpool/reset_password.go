@@ -1,35 +1,40 @@
 package userpool
 
 import (
-	"bufio"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/cmd/prompt"
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/userpool"
-	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/alecthomas/kingpin.v2"
 	"os"
-	"strings"
-	"syscall"
 )
 
 type cmdResetPassword struct {
-	Username   string
-	ClientID   string
-	ConfigFile string
-	Region     string
+	Username      string
+	Yes           bool
+	SendCodeOnly  bool
+	Code          string
+	Password      string
+	PasswordStdin bool
+	ConfigFile    string
+	Region        string
 }
 
 func (v *cmdResetPassword) run(c *kingpin.ParseContext) error {
 
+	if v.PasswordStdin && v.Code == "" && !v.SendCodeOnly {
+		return errors.New("--code is required when using --password-stdin")
+	}
+
 	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
 	sess, err := session.NewSession(awsConfig)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return err
 	}
 
 	cognitoConfig, err := config.Load(v.ConfigFile)
@@ -41,75 +46,55 @@ func (v *cmdResetPassword) run(c *kingpin.ParseContext) error {
 
 	resetter := userpool.NewPasswordResetter(&cognitoConfig, cognitoIdentityProvider)
 
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print("Are you sure you want to reset the password for ", v.Username, "? [y/n] ")
-	text, err := reader.ReadString('\n')
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	text = strings.TrimSpace(text)
-
-	if !strings.ContainsAny(text, "yY") {
-		fmt.Println("Cancelled")
-		os.Exit(0)
+	code := v.Code
+
+	// A code means the reset was already initiated, so we only need to confirm it.
+	if code == "" {
+		if !v.Yes {
+			confirmed, err := prompt.ReadConfirmation("Are you sure you want to reset the password for " + v.Username + "?")
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Cancelled")
+				return nil
+			}
+		}
+
+		err = resetter.InitResetPassword(v.Username)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("Please check your email for a password reset code.")
+
+		if v.SendCodeOnly {
+			return nil
+		}
+
+		code, err = prompt.ReadLine("Enter the password reset code: ")
+		if err != nil {
+			return errors.Wrap(err, "Failed to read code")
+		}
 	}
 
-	err = resetter.InitResetPassword(v.Username)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	fmt.Println("Please check your email for a password reset code.")
-	fmt.Print("Enter the password reset code: ")
-
-	code, err := reader.ReadString('\n')
-	code = strings.TrimSpace(code)
-	if err != nil {
-		fmt.Println(err)
-		fmt.Println("Failed to read code")
-		os.Exit(1)
-	}
-
-	fmt.Print("Enter the new password: ")
-	bytecode, err := terminal.ReadPassword(int(syscall.Stdin))
-	if err != nil {
-		fmt.Println(err)
-		fmt.Println("Failed to read password")
-		os.Exit(1)
-	}
-	password := string(bytecode)
-	password = strings.TrimSpace(password)
-
-	fmt.Println()
-	fmt.Print("Confirm the new password: ")
-	bytecode, err = terminal.ReadPassword(int(syscall.Stdin))
-	if err != nil {
-		fmt.Println(err)
-		fmt.Println()
-		fmt.Println("Failed to read password confirmation")
-		os.Exit(1)
-	}
-	confirmedPassword := string(bytecode)
-	confirmedPassword = strings.TrimSpace(confirmedPassword)
-
-	if password != confirmedPassword {
-		fmt.Println()
-		fmt.Println("Passwords do not match! Please try again.")
-		os.Exit(1)
+	password := v.Password
+	if password == "" {
+		if v.PasswordStdin {
+			password, err = prompt.ReadPasswordStdin()
+		} else {
+			password, err = prompt.ReadNewPassword()
+		}
+		if err != nil {
+			return err
+		}
 	}
 
 	err = resetter.ConfirmResetPassword(v.Username, password, code)
 	if err != nil {
-		fmt.Println(err)
-		fmt.Println()
-		fmt.Println("Failed to update password")
-		os.Exit(1)
+		return errors.Wrap(err, "Failed to update password")
 	}
 
-	fmt.Println()
 	fmt.Println("Password successfully updated.")
 
 	return nil
@@ -121,6 +106,11 @@ func ResetPassword(c *kingpin.CmdClause) {
 
 	command := c.Command("reset-password", "Resets a users Cognito Userpool password.").Action(v.run)
 	command.Flag("username", "The username").Required().StringVar(&v.Username)
+	command.Flag("yes", "Don't ask for confirmation before sending the reset code.").Short('y').Envar("COGNITO_AUTH_ASSUME_YES").BoolVar(&v.Yes)
+	command.Flag("send-code-only", "Only send the password reset code, then exit.").BoolVar(&v.SendCodeOnly)
+	command.Flag("code", "The password reset code from a previously sent reset.").Envar("COGNITO_AUTH_RESET_CODE").StringVar(&v.Code)
+	command.Flag("password", "The new password.").Envar("COGNITO_AUTH_NEW_PASSWORD").StringVar(&v.Password)
+	command.Flag("password-stdin", "Read the new password from stdin.").BoolVar(&v.PasswordStdin)
 
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
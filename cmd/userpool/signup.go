@@ -0,0 +1,77 @@
+package userpool
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/skpr/cognito-auth/cmd/prompt"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+)
+
+type cmdSignup struct {
+	Username   string
+	Password   string
+	Attributes map[string]string
+	ConfigFile string
+	Region     string
+}
+
+func (v *cmdSignup) run(c *kingpin.ParseContext) error {
+
+	password := v.Password
+	if password == "" {
+		var err error
+		password, err = prompt.ReadNewPassword()
+		if err != nil {
+			return err
+		}
+	}
+
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	signupHandler := userpool.NewSignupHandler(&cognitoConfig, cognitoIdentityProvider)
+
+	confirmed, delivery, err := signupHandler.SignUp(v.Username, password, v.Attributes)
+	if err != nil {
+		return err
+	}
+
+	if confirmed {
+		fmt.Println("You successfully signed up. You can now log in.")
+		return nil
+	}
+
+	fmt.Println("You successfully signed up.")
+	fmt.Println("A confirmation code has been sent to", delivery.Destination, "("+delivery.DeliveryMedium+").")
+	fmt.Println("Run 'userpool confirm-signup' with the code to confirm your account.")
+	return nil
+}
+
+// Signup sub-command.
+func Signup(c *kingpin.CmdClause) {
+	v := new(cmdSignup)
+
+	command := c.Command("signup", "Signs up a new user to a Cognito Userpool.").Action(v.run)
+
+	command.Flag("username", "Username for the new account").Required().StringVar(&v.Username)
+	command.Flag("password", "Password for the new account").StringVar(&v.Password)
+	command.Flag("attribute", "A user attribute in the form name=value (repeatable)").StringMapVar(&v.Attributes)
+	homeDir, _ := os.UserHomeDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
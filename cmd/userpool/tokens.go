@@ -0,0 +1,20 @@
+package userpool
+
+import (
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/skpr/cognito-auth/pkg/cache"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+)
+
+// newTokensResolver creates a tokens resolver backed by the configured creds store.
+func newTokensResolver(cognitoConfig *config.Config, cacheDir string, cognitoIdentityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) (*oauth.TokensResolver, error) {
+	tokenCache, _, err := cache.New(cognitoConfig, cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	tokensRefresher := userpool.NewTokensRefresher(cognitoConfig, tokenCache, cognitoIdentityProvider)
+	return oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), oauth.NewCacheLock(cacheDir)), nil
+}
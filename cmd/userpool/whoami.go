@@ -0,0 +1,68 @@
+package userpool
+
+import (
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/gosuri/uitable"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/userpool"
+	"gopkg.in/alecthomas/kingpin.v2"
+	"os"
+)
+
+type cmdWhoami struct {
+	ConfigFile string
+	CacheDir   string
+	Region     string
+}
+
+func (v *cmdWhoami) run(c *kingpin.ParseContext) error {
+	awsConfig := aws.NewConfig().WithRegion(v.Region).WithCredentials(credentials.AnonymousCredentials)
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return err
+	}
+
+	cognitoConfig, err := config.Load(v.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	cognitoIdentityProvider := cognitoidentityprovider.New(sess)
+	tokensResolver, err := newTokensResolver(&cognitoConfig, v.CacheDir, cognitoIdentityProvider)
+	if err != nil {
+		return err
+	}
+
+	profileHandler := userpool.NewProfileHandler(tokensResolver, cognitoIdentityProvider)
+
+	user, err := profileHandler.GetUser()
+	if err != nil {
+		return err
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.AddRow("Username:", user.Username)
+	table.AddRow("Email:", user.Attributes["email"])
+	table.AddRow("Phone:", user.Attributes["phone_number"])
+	table.AddRow("Sub:", user.Attributes["sub"])
+	fmt.Println(table)
+
+	return nil
+}
+
+// Whoami sub-command.
+func Whoami(c *kingpin.CmdClause) {
+	v := new(cmdWhoami)
+
+	command := c.Command("whoami", "Shows the logged in Cognito Userpool user.").Action(v.run)
+	homeDir, _ := os.UserHomeDir()
+	cacheDir, _ := os.UserCacheDir()
+	command.Flag("config", "The config file to use.").Default(homeDir + "/.config/cognito-auth/userpool.yml").Envar("COGNITO_AUTH_CONFIG").StringVar(&v.ConfigFile)
+	command.Flag("cache-dir", "The cache directory to use.").Default(cacheDir + "/cognito-auth").Envar("COGNITO_AUTH_CACHE_DIR").StringVar(&v.CacheDir)
+	command.Flag("region", "The AWS region").Default("ap-southeast-2").Envar("COGNITO_AUTH_REGION").StringVar(&v.Region)
+}
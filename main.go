@@ -2,6 +2,7 @@ package main
 
 import (
 	"github.com/skpr/cognito-auth/cmd"
+	"github.com/skpr/cognito-auth/cmd/admin"
 	"github.com/skpr/cognito-auth/cmd/oidc"
 	"github.com/skpr/cognito-auth/cmd/userpool"
 	"gopkg.in/alecthomas/kingpin.v2"
@@ -19,8 +20,32 @@ func main() {
 	userpool.Login(cmdUserpool)
 	userpool.Logout(cmdUserpool)
 	userpool.ResetPassword(cmdUserpool)
+	userpool.ChangePassword(cmdUserpool)
+	userpool.Signup(cmdUserpool)
+	userpool.ConfirmSignup(cmdUserpool)
+	userpool.ResendCode(cmdUserpool)
+	userpool.Whoami(cmdUserpool)
+	userpool.Attributes(cmdUserpool)
+	userpool.Devices(cmdUserpool)
+
+	cmdAdmin := app.Command("admin", "Userpool administration commands")
+	admin.CreateUser(cmdAdmin)
+	admin.DisableUser(cmdAdmin)
+	admin.EnableUser(cmdAdmin)
+	admin.ResetUserPassword(cmdAdmin)
+	admin.SetUserPassword(cmdAdmin)
+	admin.AddToGroup(cmdAdmin)
+	admin.ListUsers(cmdAdmin)
+	admin.GlobalSignout(cmdAdmin)
+	admin.PasswordPolicy(cmdAdmin)
 
 	cmd.ConsoleSignIn(app)
+	cmd.Status(app)
+	cmd.Agent(app)
 
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	command, err := app.Parse(os.Args[1:])
+	if exitErr, ok := err.(*cmd.ExitError); ok {
+		os.Exit(exitErr.Code)
+	}
+	kingpin.MustParse(command, err)
 }
@@ -0,0 +1,201 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+// Actions supported by the agent protocol.
+const (
+	ActionPing        = "ping"
+	ActionCredentials = "credentials"
+)
+
+// TokensGetter gets tokens, refreshing if needed.
+type TokensGetter interface {
+	GetTokens() (oauth.Tokens, error)
+}
+
+// CredentialsGetter gets AWS credentials, refreshing if needed.
+type CredentialsGetter interface {
+	GetAwsCredentials() (awscreds.Credentials, error)
+}
+
+// Request is sent by clients as a single line of JSON.
+type Request struct {
+	Action string `json:"action"`
+}
+
+// Response is returned to clients as a single line of JSON.
+type Response struct {
+	Credentials *Credentials `json:"credentials,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// Credentials are the AWS credentials returned by the agent.
+type Credentials struct {
+	AccessKeyID     string    `json:"access_key_id"`
+	SecretAccessKey string    `json:"secret_access_key"`
+	SessionToken    string    `json:"session_token"`
+	Expiration      time.Time `json:"expiration"`
+}
+
+// Agent keeps tokens and credentials fresh, and serves them to clients.
+type Agent struct {
+	tokensGetter      TokensGetter
+	credentialsGetter CredentialsGetter
+	interval          time.Duration
+	notify            func(message string)
+
+	// mutex serialises refreshes, as the caches aren't safe for concurrent use.
+	mutex   sync.Mutex
+	expired bool
+}
+
+// New creates a new agent, which checks for expiry every interval.
+func New(tokensGetter TokensGetter, credentialsGetter CredentialsGetter, interval time.Duration, notify func(message string)) *Agent {
+	return &Agent{
+		tokensGetter:      tokensGetter,
+		credentialsGetter: credentialsGetter,
+		interval:          interval,
+		notify:            notify,
+	}
+}
+
+// Run refreshes the tokens and credentials ahead of expiry until the context is done.
+func (a *Agent) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+	for {
+		_, _ = a.refresh()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh gets the tokens and credentials, which refreshes them if they are close to expiry.
+//
+// The user is notified once when they need to log in again, because the
+// refresh token has expired. Other errors, such as network failures, are
+// logged, and the refresh is retried on the next interval.
+func (a *Agent) refresh() (awscreds.Credentials, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	_, err := a.tokensGetter.GetTokens()
+	if err == nil {
+		var creds awscreds.Credentials
+		creds, err = a.credentialsGetter.GetAwsCredentials()
+		if err == nil {
+			a.expired = false
+			return creds, nil
+		}
+	}
+
+	if !oauth.IsLoginRequired(err) {
+		log.Println("Failed to refresh, retrying:", err)
+		return awscreds.Credentials{}, err
+	}
+
+	if !a.expired {
+		a.expired = true
+		a.notify("Your session has expired. Please log in again.")
+	}
+
+	return awscreds.Credentials{}, err
+}
+
+// Listen listens on the unix socket, refusing to take over the socket of a running agent.
+//
+// A socket left behind by an agent which didn't shut down cleanly is removed.
+func Listen(socket string) (net.Listener, error) {
+	err := Ping(socket)
+	if err == nil {
+		return nil, errors.Errorf("an agent is already listening on %s", socket)
+	}
+	if !isNotListening(err) {
+		return nil, errors.Wrapf(err, "failed to check for an agent listening on %s", socket)
+	}
+	_ = os.Remove(socket)
+
+	err = os.MkdirAll(filepath.Dir(socket), 0700)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create socket directory")
+	}
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen on socket")
+	}
+	err = os.Chmod(socket, 0600)
+	if err != nil {
+		listener.Close()
+		return nil, errors.Wrap(err, "failed to set socket permissions")
+	}
+
+	return listener, nil
+}
+
+// Serve handles client connections on the listener until it is closed.
+func (a *Agent) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go a.handle(conn)
+	}
+}
+
+// handle responds to the requests on a client connection.
+func (a *Agent) handle(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+	encoder := json.NewEncoder(conn)
+	for scanner.Scan() {
+		var request Request
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			_ = encoder.Encode(Response{Error: "invalid request"})
+			continue
+		}
+		_ = encoder.Encode(a.respond(request))
+	}
+}
+
+// respond builds the response for a request.
+func (a *Agent) respond(request Request) Response {
+	switch request.Action {
+	case ActionPing:
+		return Response{}
+	case ActionCredentials:
+		creds, err := a.refresh()
+		if err != nil {
+			return Response{Error: errors.Wrap(err, "failed to get credentials").Error()}
+		}
+		return Response{
+			Credentials: &Credentials{
+				AccessKeyID:     creds.AccessKey,
+				SecretAccessKey: creds.SecretAccessKey,
+				SessionToken:    creds.SessionToken,
+				Expiration:      creds.Expiry,
+			},
+		}
+	default:
+		return Response{Error: "unknown action: " + request.Action}
+	}
+}
@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+type fakeResolver struct {
+	calls int
+	err   error
+}
+
+func (r *fakeResolver) GetTokens() (oauth.Tokens, error) {
+	return oauth.Tokens{}, r.err
+}
+
+func (r *fakeResolver) GetAwsCredentials() (awscreds.Credentials, error) {
+	r.calls++
+	if r.err != nil {
+		return awscreds.Credentials{}, r.err
+	}
+	return awscreds.Credentials{
+		AccessKey:       "ABCDEFGHIJKLMNOP",
+		SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
+		SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU",
+		Expiry:          time.Now().UTC().Add(3600 * time.Second).Truncate(time.Second),
+	}, nil
+}
+
+func TestServe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agent")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	resolver := &fakeResolver{}
+	var notifications []string
+	a := New(resolver, resolver, time.Minute, func(message string) {
+		notifications = append(notifications, message)
+	})
+	go a.Serve(listener)
+
+	creds, err := GetCredentials(socket)
+	assert.Nil(t, err)
+	assert.Equal(t, "ABCDEFGHIJKLMNOP", creds.AccessKeyID, "access_key_id was set")
+	assert.Equal(t, "ABCDEFGHIJKLMNOP1234567890", creds.SecretAccessKey, "secret_access_key was set")
+	assert.Equal(t, "1234567890ABCDEFGHIJKLMNOPQRSTU", creds.SessionToken, "session_token was set")
+	assert.Empty(t, notifications)
+
+	resolver.err = awserr.New(cognitoidentityprovider.ErrCodeNotAuthorizedException, "Refresh Token has expired", nil)
+	_, err = GetCredentials(socket)
+	assert.NotNil(t, err)
+	_, err = GetCredentials(socket)
+	assert.NotNil(t, err)
+	assert.Len(t, notifications, 1, "user was only notified once")
+}
+
+func TestRefreshNotifications(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		notified bool
+	}{
+		{name: "refreshed"},
+		{name: "network error", err: errors.Wrap(errors.New("dial tcp: lookup cognito-idp.ap-southeast-2.amazonaws.com: no such host"), "Failed to refresh tokens")},
+		{name: "throttled", err: awserr.New("TooManyRequestsException", "Rate exceeded", nil)},
+		{name: "refresh token expired", err: errors.Wrap(awserr.New(cognitoidentityprovider.ErrCodeNotAuthorizedException, "Refresh Token has expired", nil), "Failed to refresh tokens"), notified: true},
+		{name: "no refresh token", err: errors.Wrap(oauth.ErrNoRefreshToken, "Failed to load oauth tokens"), notified: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := &fakeResolver{err: test.err}
+			var notifications []string
+			a := New(resolver, resolver, time.Minute, func(message string) {
+				notifications = append(notifications, message)
+			})
+
+			_, err := a.refresh()
+			assert.Equal(t, test.err, err)
+			if test.notified {
+				assert.Equal(t, []string{"Your session has expired. Please log in again."}, notifications)
+			} else {
+				assert.Empty(t, notifications)
+			}
+		})
+	}
+}
+
+func TestListen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agent")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "agent.sock")
+
+	// A stale socket file is replaced.
+	stale, err := net.Listen("unix", socket)
+	assert.Nil(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	listener, err := Listen(socket)
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	// An agent with no cached credentials still refuses a second start.
+	resolver := &fakeResolver{err: errors.New("no cached tokens")}
+	a := New(resolver, resolver, time.Minute, func(message string) {})
+	go a.Serve(listener)
+
+	_, err = GetCredentials(socket)
+	assert.NotNil(t, err)
+
+	_, err = Listen(socket)
+	assert.EqualError(t, err, "an agent is already listening on "+socket)
+	assert.Nil(t, Ping(socket), "first agent is still running")
+}
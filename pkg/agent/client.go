@@ -0,0 +1,78 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Ping checks that an agent is listening on the socket and responding to requests.
+func Ping(socket string) error {
+	conn, err := net.DialTimeout("unix", socket, 5*time.Second)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to agent")
+	}
+	defer conn.Close()
+
+	err = json.NewEncoder(conn).Encode(Request{Action: ActionPing})
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+
+	var response Response
+	err = json.NewDecoder(bufio.NewReader(conn)).Decode(&response)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+
+	return nil
+}
+
+// isNotListening returns true if the error is from dialing a socket nothing is listening on.
+func isNotListening(err error) bool {
+	opErr, ok := errors.Cause(err).(*net.OpError)
+	if !ok || opErr.Op != "dial" {
+		return false
+	}
+	sysErr, ok := opErr.Err.(*os.SyscallError)
+	if !ok {
+		return false
+	}
+	return sysErr.Err == syscall.ECONNREFUSED || sysErr.Err == syscall.ENOENT
+}
+
+// GetCredentials gets the AWS credentials from the agent listening on the socket.
+func GetCredentials(socket string) (Credentials, error) {
+	conn, err := net.DialTimeout("unix", socket, 5*time.Second)
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "failed to connect to agent")
+	}
+	defer conn.Close()
+
+	err = json.NewEncoder(conn).Encode(Request{Action: ActionCredentials})
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "failed to send request")
+	}
+
+	var response Response
+	err = json.NewDecoder(bufio.NewReader(conn)).Decode(&response)
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "failed to read response")
+	}
+	if response.Error != "" {
+		return Credentials{}, errors.New(response.Error)
+	}
+	if response.Credentials == nil {
+		return Credentials{}, errors.New("no credentials in response")
+	}
+
+	return *response.Credentials, nil
+}
@@ -11,6 +11,8 @@ type Credentials struct {
 	SecretAccessKey string    `yaml:"secret_access_key"`
 	SessionToken    string    `yaml:"session_token"`
 	Expiry          time.Time `yaml:"expiry"`
+	Issued          time.Time `yaml:"issued,omitempty"`
+	IdentityID      string    `yaml:"identity_id,omitempty"`
 }
 
 // Validate the awscreds credentials.
@@ -30,7 +32,19 @@ func (c *Credentials) Validate() error {
 	return nil
 }
 
-// HasExpired checks if the credentials has expired
-func (c *Credentials) HasExpired() bool {
-	return c.Expiry.Before(time.Now())
+// HasExpired checks if the credentials has expired, or will expire within the margin.
+func (c *Credentials) HasExpired(margin time.Duration) bool {
+	return c.RefreshAt(margin).Before(time.Now())
+}
+
+// RefreshAt returns when the credentials should be refreshed, which is the
+// expiry less the margin.
+//
+// The margin is capped at half the lifetime of the credentials, so credentials
+// which don't live much longer than the margin aren't refreshed on every call.
+func (c *Credentials) RefreshAt(margin time.Duration) time.Time {
+	if !c.Issued.IsZero() && margin > c.Expiry.Sub(c.Issued)/2 {
+		margin = c.Expiry.Sub(c.Issued) / 2
+	}
+	return c.Expiry.Add(-margin)
 }
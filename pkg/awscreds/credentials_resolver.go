@@ -2,37 +2,50 @@ package awscreds
 
 import (
 	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity/cognitoidentityiface"
 	"github.com/pkg/errors"
 	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/lock"
 	"github.com/skpr/cognito-auth/pkg/oauth"
+	"time"
 )
 
 // CredentialsResolver struct
 type CredentialsResolver struct {
 	cognitoConfig    config.Config
 	credentialsCache CredentialsCache
-	tokensResolver   oauth.TokensResolver
-	cognitoIdentity  cognitoidentity.CognitoIdentity
+	tokensResolver   *oauth.TokensResolver
+	cognitoIdentity  cognitoidentityiface.CognitoIdentityAPI
+	locker           lock.Locker
 }
 
 // NewCredentialsResolver creates a new credentials resolver.
-func NewCredentialsResolver(cognitoConfig *config.Config, credentialsCache CredentialsCache, tokensResolver *oauth.TokensResolver, cognitoIdentity *cognitoidentity.CognitoIdentity) *CredentialsResolver {
+//
+// The locker is held while refreshing, so concurrent processes only refresh once.
+func NewCredentialsResolver(cognitoConfig *config.Config, credentialsCache CredentialsCache, tokensResolver *oauth.TokensResolver, cognitoIdentity cognitoidentityiface.CognitoIdentityAPI, locker lock.Locker) *CredentialsResolver {
 	return &CredentialsResolver{
 		cognitoConfig:    *cognitoConfig,
 		credentialsCache: credentialsCache,
-		tokensResolver:   *tokensResolver,
-		cognitoIdentity:  *cognitoIdentity,
+		tokensResolver:   tokensResolver,
+		cognitoIdentity:  cognitoIdentity,
+		locker:           locker,
 	}
 }
 
 // GetAwsCredentials returns the AWS Credentials, refreshing if expired.
 func (r *CredentialsResolver) GetAwsCredentials() (Credentials, error) {
 
+	err := r.locker.Lock()
+	if err != nil {
+		return Credentials{}, errors.Wrap(err, "Failed to lock credentials cache")
+	}
+	defer r.locker.Unlock()
+
 	creds, err := r.credentialsCache.Get()
 	if err != nil {
 		return Credentials{}, errors.Wrap(err, "Could not load awscreds credentials")
 	}
-	if creds.HasExpired() {
+	if creds.HasExpired(r.cognitoConfig.ExpiryMargin()) {
 		creds, err = r.RefreshAwsCredentials()
 	}
 	if err != nil {
@@ -68,6 +81,7 @@ func (r *CredentialsResolver) GetTempCredentials(idToken string) (Credentials, e
 		return Credentials{}, errors.Wrap(err, "Failed to get cognito user id")
 	}
 
+	issued := time.Now()
 	credsOutput, err := r.cognitoIdentity.GetCredentialsForIdentity(&cognitoidentity.GetCredentialsForIdentityInput{
 		IdentityId: idOutput.IdentityId,
 		Logins:     logins,
@@ -81,6 +95,8 @@ func (r *CredentialsResolver) GetTempCredentials(idToken string) (Credentials, e
 		SecretAccessKey: *credsOutput.Credentials.SecretKey,
 		SessionToken:    *credsOutput.Credentials.SessionToken,
 		Expiry:          *credsOutput.Credentials.Expiration,
+		Issued:          issued,
+		IdentityID:      *idOutput.IdentityId,
 	}
 
 	err = r.credentialsCache.Put(credentials)
@@ -0,0 +1,136 @@
+package awscreds
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity"
+	"github.com/aws/aws-sdk-go/service/cognitoidentity/cognitoidentityiface"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/lock"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+// fakeIdentity returns credentials for any identity, counting the requests.
+type fakeIdentity struct {
+	cognitoidentityiface.CognitoIdentityAPI
+
+	getIDInput *cognitoidentity.GetIdInput
+	err        error
+	calls      int
+}
+
+func (f *fakeIdentity) GetId(input *cognitoidentity.GetIdInput) (*cognitoidentity.GetIdOutput, error) {
+	f.getIDInput = input
+	f.calls++
+	return &cognitoidentity.GetIdOutput{
+		IdentityId: aws.String("ap-southeast-2:0123"),
+	}, f.err
+}
+
+func (f *fakeIdentity) GetCredentialsForIdentity(input *cognitoidentity.GetCredentialsForIdentityInput) (*cognitoidentity.GetCredentialsForIdentityOutput, error) {
+	return &cognitoidentity.GetCredentialsForIdentityOutput{
+		IdentityId: input.IdentityId,
+		Credentials: &cognitoidentity.Credentials{
+			AccessKeyId:  aws.String("REFRESHEDACCESSKEY"),
+			SecretKey:    aws.String("ABCDEFGHIJKLMNOP1234567890"),
+			SessionToken: aws.String("1234567890ABCDEFGHIJKLMNOPQRSTU"),
+			Expiration:   aws.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil
+}
+
+// fakeRefresher fails, as the tests only use cached tokens which haven't expired.
+type fakeRefresher struct{}
+
+func (fakeRefresher) RefreshOAuthTokens(refreshToken string) (oauth.Tokens, error) {
+	return oauth.Tokens{}, errors.New("unexpected refresh")
+}
+
+func TestGetAwsCredentials(t *testing.T) {
+	tests := []struct {
+		name          string
+		expiry        time.Time
+		cached        bool
+		err           error
+		wantAccessKey string
+		wantCalls     int
+		wantErr       string
+	}{
+		{
+			name:          "cached",
+			cached:        true,
+			expiry:        time.Now().Add(time.Hour),
+			wantAccessKey: "CACHEDACCESSKEY",
+		},
+		{
+			name:          "expired",
+			cached:        true,
+			expiry:        time.Now().Add(-time.Minute),
+			wantAccessKey: "REFRESHEDACCESSKEY",
+			wantCalls:     1,
+		},
+		{
+			name:          "expires within the margin",
+			cached:        true,
+			expiry:        time.Now().Add(2 * time.Minute),
+			wantAccessKey: "REFRESHEDACCESSKEY",
+			wantCalls:     1,
+		},
+		{
+			name:    "not logged in",
+			wantErr: "Could not load awscreds credentials: not found: credentials",
+		},
+		{
+			name:      "refresh failed",
+			cached:    true,
+			expiry:    time.Now().Add(-time.Minute),
+			err:       errors.New("NotAuthorizedException"),
+			wantCalls: 1,
+			wantErr:   "Could not refresh awscreds credentials: Failed to get cognito user id: NotAuthorizedException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{
+				IdentityPoolID:     "WXYZ0123456789",
+				IdentityProviderID: "LMNOPQRTSUV",
+				RefreshMargin:      5 * time.Minute,
+			}
+
+			tokenCache := oauth.NewMemoryCache(false)
+			assert.Nil(t, tokenCache.Put(oauth.Tokens{IDToken: "0123456789ABCDEF", Expiry: time.Now().Add(time.Hour)}))
+			tokensResolver := oauth.NewTokensResolver(tokenCache, fakeRefresher{}, cognitoConfig.ExpiryMargin(), lock.Nop{})
+
+			credentialsCache := NewMemoryCache(false)
+			if tt.cached {
+				assert.Nil(t, credentialsCache.Put(Credentials{AccessKey: "CACHEDACCESSKEY", Expiry: tt.expiry}))
+			}
+
+			identity := &fakeIdentity{err: tt.err}
+			resolver := NewCredentialsResolver(cognitoConfig, credentialsCache, tokensResolver, identity, lock.Nop{})
+			creds, err := resolver.GetAwsCredentials()
+
+			assert.Equal(t, tt.wantCalls, identity.calls)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantAccessKey, creds.AccessKey)
+
+			if tt.wantCalls > 0 {
+				assert.Equal(t, "0123456789ABCDEF", *identity.getIDInput.Logins["LMNOPQRTSUV"])
+				assert.Equal(t, "ap-southeast-2:0123", creds.IdentityID)
+				cached, err := credentialsCache.Get()
+				assert.Nil(t, err)
+				assert.Equal(t, creds, cached)
+			}
+		})
+	}
+}
@@ -2,14 +2,15 @@ package awscreds
 
 import (
 	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/lock"
+	"github.com/skpr/cognito-auth/pkg/securefile"
 	"gopkg.in/yaml.v2"
-	"io/ioutil"
 	"os"
-	"path"
 )
 
 const (
-	filename = "aws_credentials.yml"
+	filename     = "aws_credentials.yml"
+	lockFilename = "aws_credentials.lock"
 )
 
 // FileCache handles getting and putting credentials from a cache
@@ -34,7 +35,7 @@ func (c *FileCache) Get() (Credentials, error) {
 		return Credentials{}, errors.Wrap(err, "Credentials file does not exist")
 	}
 
-	data, err := ioutil.ReadFile(c.filename)
+	data, err := securefile.ReadFile(c.filename)
 	if err != nil {
 		return Credentials{}, errors.Wrap(err, "Failed to read credentials file")
 	}
@@ -54,20 +55,11 @@ func (c *FileCache) Get() (Credentials, error) {
 
 // Put saves awscreds credentials to cache.
 func (c *FileCache) Put(credentials Credentials) error {
-	// Create parent directory if it doesn't exist.
-	if _, err := os.Stat(c.filename); os.IsNotExist(err) {
-		dir := path.Dir(c.filename)
-		err := os.MkdirAll(dir, os.ModePerm)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create directory")
-		}
-	}
-
 	credBytes, err := yaml.Marshal(credentials)
 	if err != nil {
 		return errors.Wrap(err, "Failed to marshal credentials")
 	}
-	err = ioutil.WriteFile(c.filename, credBytes, 0644)
+	err = securefile.WriteFile(c.filename, credBytes)
 	if err != nil {
 		return errors.Wrap(err, "Failed to write credentials to file")
 	}
@@ -82,3 +74,11 @@ func (c *FileCache) Delete(credentials Credentials) error {
 	}
 	return nil
 }
+
+// NewCacheLock creates the lock held while refreshing the credentials cache.
+//
+// The lock lives in the cache directory for all creds stores, so that
+// refreshes of the keychain are also serialised.
+func NewCacheLock(cacheDir string) *lock.FileLock {
+	return lock.NewFileLock(cacheDir + "/" + lockFilename)
+}
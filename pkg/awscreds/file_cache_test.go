@@ -2,6 +2,8 @@ package awscreds
 
 import (
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 )
@@ -15,9 +17,14 @@ func TestCredentialsCache(t *testing.T) {
 		SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
 		SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU:VWXYZ|}{)(*&^%$#@!",
 		Expiry:          expiry,
+		IdentityID:      "ap-southeast-2:01234567-89ab-cdef-0123-456789abcdef",
 	}
-	cache := NewFileCache("/tmp/skpr/credentials.yml")
-	err := cache.Put(credentials)
+	dir, err := ioutil.TempDir("", "awscreds")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cache := NewFileCache(dir)
+	err = cache.Put(credentials)
 	assert.Nil(t, err)
 
 	credentials, err = cache.Get()
@@ -26,7 +33,8 @@ func TestCredentialsCache(t *testing.T) {
 	assert.Equal(t, "ABCDEFGHIJKLMNOP1234567890", credentials.SecretAccessKey, "secret_access_key was set")
 	assert.Equal(t, "1234567890ABCDEFGHIJKLMNOPQRSTU:VWXYZ|}{)(*&^%$#@!", credentials.SessionToken, "session_token was set")
 	assert.Equal(t, expiry, credentials.Expiry, "expiry was set")
-	assert.False(t, credentials.HasExpired())
+	assert.Equal(t, "ap-southeast-2:01234567-89ab-cdef-0123-456789abcdef", credentials.IdentityID, "identity_id was set")
+	assert.False(t, credentials.HasExpired(0))
 
 }
 
@@ -40,5 +48,19 @@ func TestHasExpired(t *testing.T) {
 		Expiry:          expiry,
 	}
 
-	assert.True(t, credentials.HasExpired())
+	assert.True(t, credentials.HasExpired(0))
+
+	credentials.Expiry = time.Now().UTC().Add(time.Duration(60 * time.Second))
+	assert.False(t, credentials.HasExpired(0))
+	assert.True(t, credentials.HasExpired(time.Duration(300*time.Second)))
+
+	// The margin is capped at half the lifetime, which is 2m30s here.
+	now := time.Now().UTC()
+	credentials.Issued = now.Add(-time.Minute)
+	credentials.Expiry = now.Add(4 * time.Minute)
+	assert.False(t, credentials.HasExpired(330*time.Second))
+	assert.Equal(t, credentials.Expiry.Add(-150*time.Second), credentials.RefreshAt(330*time.Second))
+	credentials.Issued = now.Add(-3 * time.Minute)
+	credentials.Expiry = now.Add(2 * time.Minute)
+	assert.True(t, credentials.HasExpired(330*time.Second))
 }
@@ -0,0 +1,63 @@
+package awscreds
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// MemoryCache handles caching aws creds in memory, for embedding without
+// touching the disk or keychain. It is safe for concurrent use.
+type MemoryCache struct {
+	mutex        sync.RWMutex
+	credentials  *Credentials
+	evictExpired bool
+}
+
+// NewMemoryCache creates a new memory cache.
+//
+// If evictExpired is set, credentials are evicted once they expire.
+func NewMemoryCache(evictExpired bool) *MemoryCache {
+	return &MemoryCache{
+		evictExpired: evictExpired,
+	}
+}
+
+// Get gets creds from memory.
+func (c *MemoryCache) Get() (Credentials, error) {
+	c.mutex.RLock()
+	credentials := c.credentials
+	c.mutex.RUnlock()
+
+	if credentials == nil {
+		return Credentials{}, errors.New("not found: credentials")
+	}
+
+	if c.evictExpired && credentials.HasExpired(0) {
+		c.mutex.Lock()
+		// Only evict if the credentials weren't replaced in the meantime.
+		if c.credentials == credentials {
+			c.credentials = nil
+		}
+		c.mutex.Unlock()
+		return Credentials{}, errors.New("not found: credentials have expired")
+	}
+
+	return *credentials, nil
+}
+
+// Put puts creds in memory.
+func (c *MemoryCache) Put(credentials Credentials) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.credentials = &credentials
+	return nil
+}
+
+// Delete deletes creds from memory.
+func (c *MemoryCache) Delete(credentials Credentials) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.credentials = nil
+	return nil
+}
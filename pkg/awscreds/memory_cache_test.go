@@ -0,0 +1,75 @@
+package awscreds
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(false)
+
+	_, err := cache.Get()
+	assert.Error(t, err)
+
+	credentials := Credentials{
+		AccessKey:       "ABCDEFGHIJKLMNOP",
+		SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
+		SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU",
+		Expiry:          time.Now().Add(-4000 * time.Second),
+	}
+	assert.Nil(t, cache.Put(credentials))
+
+	cached, err := cache.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, credentials, cached)
+
+	assert.Nil(t, cache.Delete(credentials))
+	_, err = cache.Get()
+	assert.Error(t, err)
+}
+
+func TestMemoryCacheEvictExpired(t *testing.T) {
+	cache := NewMemoryCache(true)
+
+	credentials := Credentials{
+		AccessKey:       "ABCDEFGHIJKLMNOP",
+		SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
+		SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU",
+		Expiry:          time.Now().Add(4000 * time.Second),
+	}
+	assert.Nil(t, cache.Put(credentials))
+
+	_, err := cache.Get()
+	assert.Nil(t, err)
+
+	credentials.Expiry = time.Now().Add(-4000 * time.Second)
+	assert.Nil(t, cache.Put(credentials))
+
+	_, err = cache.Get()
+	assert.Error(t, err)
+}
+
+func TestMemoryCacheConcurrent(t *testing.T) {
+	cache := NewMemoryCache(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			credentials := Credentials{
+				AccessKey:       "ABCDEFGHIJKLMNOP",
+				SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
+				SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU",
+				Expiry:          time.Now().Add(4000 * time.Second),
+			}
+			assert.Nil(t, cache.Put(credentials))
+			_, err := cache.Get()
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+}
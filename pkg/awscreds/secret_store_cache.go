@@ -6,23 +6,23 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-// KeychainCache handles caching aws creds in the keychain.
-type KeychainCache struct {
-	keychain secrets.Keychain
+// SecretStoreCache handles caching aws creds in a secret store.
+type SecretStoreCache struct {
+	store secrets.SecretStore
 }
 
-// NewKeychainCache creates a new keychain cache.
-func NewKeychainCache(keychain *secrets.Keychain) *KeychainCache {
-	return &KeychainCache{
-		keychain: *keychain,
+// NewSecretStoreCache creates a new secret store cache.
+func NewSecretStoreCache(store secrets.SecretStore) *SecretStoreCache {
+	return &SecretStoreCache{
+		store: store,
 	}
 }
 
 // Get gets creds from the cache.
-func (k KeychainCache) Get() (Credentials, error) {
+func (k SecretStoreCache) Get() (Credentials, error) {
 	var credentials Credentials
 
-	data, err := k.keychain.Get()
+	data, err := k.store.Get()
 	if err != nil {
 		return Credentials{}, errors.Wrap(err, "failed to get credentials")
 	}
@@ -41,13 +41,13 @@ func (k KeychainCache) Get() (Credentials, error) {
 }
 
 // Put puts creds in the cache.
-func (k KeychainCache) Put(credentials Credentials) error {
+func (k SecretStoreCache) Put(credentials Credentials) error {
 	data, err := yaml.Marshal(credentials)
 	if err != nil {
 		return errors.Wrap(err, "Failed to marshal credentials")
 	}
 
-	if err := k.keychain.Put(string(data)); err != nil {
+	if err := k.store.Put(string(data)); err != nil {
 		return errors.Wrap(err, "failed to put credentials")
 	}
 
@@ -55,8 +55,8 @@ func (k KeychainCache) Put(credentials Credentials) error {
 }
 
 // Delete deletes creds from the cache.
-func (k KeychainCache) Delete(credentials Credentials) error {
-	if err := k.keychain.Delete(); err != nil {
+func (k SecretStoreCache) Delete(credentials Credentials) error {
+	if err := k.store.Delete(); err != nil {
 		return errors.Wrap(err, "failed to delete credentials")
 	}
 	return nil
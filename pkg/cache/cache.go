@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"os/user"
+
+	"github.com/pkg/errors"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+	"github.com/skpr/cognito-auth/pkg/secrets"
+)
+
+// currentUsername gets the username of the current user, which is the keychain account.
+var currentUsername = defaultCurrentUsername
+
+// defaultCurrentUsername looks up the current user.
+func defaultCurrentUsername() (string, error) {
+	currentUser, err := user.Current()
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to get current user")
+	}
+	return currentUser.Username, nil
+}
+
+// New creates the tokens and credentials caches for the configured creds store.
+//
+// The current user is only looked up for the keychain account, as it isn't
+// available in containers without a passwd entry. Unknown creds stores are an error.
+func New(cognitoConfig *config.Config, cacheDir string) (oauth.TokenCache, awscreds.CredentialsCache, error) {
+	if cognitoConfig.CredsStore == "" || cognitoConfig.CredsStore == secrets.StoreFile {
+		return oauth.NewFileCache(cacheDir), awscreds.NewFileCache(cacheDir), nil
+	}
+
+	var username string
+	if cognitoConfig.CredsStore == secrets.StoreNative {
+		var err error
+		username, err = currentUsername()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	tokenStore, credentialsStore, err := secrets.NewStores(cognitoConfig, cacheDir, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oauth.NewSecretStoreCache(tokenStore), awscreds.NewSecretStoreCache(credentialsStore), nil
+}
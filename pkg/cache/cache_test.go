@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+	"github.com/skpr/cognito-auth/pkg/secrets"
+)
+
+func TestNew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// The current user is only needed for the keychain account.
+	currentUsername = func() (string, error) {
+		return "", errors.New("unknown userid 1000")
+	}
+	defer func() {
+		currentUsername = defaultCurrentUsername
+	}()
+
+	cognitoConfig := &config.Config{}
+
+	tokenCache, credentialsCache, err := New(cognitoConfig, dir)
+	assert.Nil(t, err)
+	assert.IsType(t, &oauth.FileCache{}, tokenCache)
+	assert.IsType(t, &awscreds.FileCache{}, credentialsCache)
+
+	os.Setenv(secrets.PassphraseEnv, "correct horse battery staple")
+	defer os.Unsetenv(secrets.PassphraseEnv)
+
+	cognitoConfig.CredsStore = secrets.StoreEncryptedFile
+	tokenCache, credentialsCache, err = New(cognitoConfig, dir)
+	assert.Nil(t, err)
+	assert.IsType(t, &oauth.SecretStoreCache{}, tokenCache)
+	assert.IsType(t, &awscreds.SecretStoreCache{}, credentialsCache)
+
+	cognitoConfig.CredsStore = secrets.StoreNative
+	_, _, err = New(cognitoConfig, dir)
+	assert.EqualError(t, err, "unknown userid 1000")
+
+	cognitoConfig.CredsStore = "keychain"
+	_, _, err = New(cognitoConfig, dir)
+	assert.EqualError(t, err, "unsupported creds_store: keychain")
+}
@@ -5,24 +5,51 @@ import (
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
 	"os"
+	"time"
 )
 
-const defaultPort = 8080
+const (
+	defaultPort          = 8080
+	defaultRefreshMargin = 5 * time.Minute
+	defaultClockSkew     = 30 * time.Second
+)
+
+// DefaultConsoleFederationURL is the global console sign-in federation endpoint.
+const DefaultConsoleFederationURL = "https://signin.aws.amazon.com/federation"
 
 // Config type
 type Config struct {
-	ClientID           string `yaml:"client_id"`
-	ClientSecret       string `yaml:"client_secret"`
-	IdentityPoolID     string `yaml:"identity_pool_id"`
-	IdentityProviderID string `yaml:"identity_provider_id"`
-	AuthURL            string `yaml:"auth_url"`
-	TokenURL           string `yaml:"token_url"`
-	ConsoleDestination string `yaml:"console_destination"`
-	ConsoleIssuer      string `yaml:"console_issuer"`
-	CredsStore         string `yaml:"creds_store,omitempty"`
-	CredsOAuthKey      string `yaml:"creds_oauth_key,omitempty"`
-	CredsAwsKey        string `yaml:"creds_aws_key,omitempty"`
-	ListenPort         int    `yaml:"listen_port,omitempty"`
+	ClientID              string            `yaml:"client_id"`
+	ClientSecret          string            `yaml:"client_secret"`
+	IdentityPoolID        string            `yaml:"identity_pool_id"`
+	IdentityProviderID    string            `yaml:"identity_provider_id"`
+	UserPoolID            string            `yaml:"user_pool_id,omitempty"`
+	AuthURL               string            `yaml:"auth_url"`
+	TokenURL              string            `yaml:"token_url"`
+	ConsoleDestination    string            `yaml:"console_destination"`
+	ConsoleIssuer         string            `yaml:"console_issuer"`
+	ConsoleServices       map[string]string `yaml:"console_services,omitempty"`
+	ConsoleFederationURL  string            `yaml:"console_federation_url,omitempty"`
+	ConsoleAccounts       map[string]string `yaml:"console_accounts,omitempty"`
+	CredsStore            string            `yaml:"creds_store,omitempty"`
+	CredsOAuthKey         string            `yaml:"creds_oauth_key,omitempty"`
+	CredsAwsKey           string            `yaml:"creds_aws_key,omitempty"`
+	CredsKeyFile          string            `yaml:"creds_key_file,omitempty"`
+	CredsOnePasswordVault string            `yaml:"creds_1password_vault,omitempty"`
+	CredsVaultMount       string            `yaml:"creds_vault_mount,omitempty"`
+	ListenPort            int               `yaml:"listen_port,omitempty"`
+	RefreshMargin         time.Duration     `yaml:"refresh_margin,omitempty"`
+	ClockSkew             time.Duration     `yaml:"clock_skew,omitempty"`
+	PasswordPolicy        *PasswordPolicy   `yaml:"password_policy,omitempty"`
+}
+
+// PasswordPolicy type
+type PasswordPolicy struct {
+	MinimumLength    int  `yaml:"minimum_length"`
+	RequireLowercase bool `yaml:"require_lowercase"`
+	RequireUppercase bool `yaml:"require_uppercase"`
+	RequireNumbers   bool `yaml:"require_numbers"`
+	RequireSymbols   bool `yaml:"require_symbols"`
 }
 
 // Load load awscreds credentials from a file.
@@ -38,7 +65,10 @@ func Load(file string) (Config, error) {
 	}
 
 	config := Config{
-		ListenPort: defaultPort,
+		ListenPort:           defaultPort,
+		RefreshMargin:        defaultRefreshMargin,
+		ClockSkew:            defaultClockSkew,
+		ConsoleFederationURL: DefaultConsoleFederationURL,
 	}
 	err = yaml.Unmarshal(data, &config)
 	if err != nil {
@@ -53,6 +83,21 @@ func Load(file string) (Config, error) {
 	return config, nil
 }
 
+// ExpiryMargin is how long before expiry tokens and credentials are refreshed,
+// allowing for the local clock being behind.
+func (c *Config) ExpiryMargin() time.Duration {
+	return c.RefreshMargin + c.ClockSkew
+}
+
+// ConsoleServiceDestination returns the console destination for a service shortcut.
+func (c *Config) ConsoleServiceDestination(service string) (string, error) {
+	destination, ok := c.ConsoleServices[service]
+	if !ok {
+		return "", errors.Errorf("not found: console_services.%s", service)
+	}
+	return destination, nil
+}
+
 // Validate the awscreds credentials.
 func (c *Config) Validate() error {
 	if c.IdentityPoolID == "" {
@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -10,12 +11,30 @@ func TestLoad(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "LMNOPQRTSUV", c.IdentityProviderID, "identity_provider_id was set")
 	assert.Equal(t, "WXYZ0123456789", c.IdentityPoolID, "identity_pool_id was set")
+	assert.Equal(t, "ap-southeast-2_ABCDEFGHI", c.UserPoolID, "user_pool_id was set")
 	assert.Equal(t, "ABCDEFGHIJK", c.ClientID, "client_id was set")
 	assert.Equal(t, "ASDFGHKL", c.ClientSecret, "client_secret was set")
 	assert.Equal(t, "https://console.awscreds.amazon.com/cloudwatch", c.ConsoleDestination, "console_destination was set")
 	assert.Equal(t, "example.com", c.ConsoleIssuer, "console_issuer was set")
+	assert.Equal(t, "https://signin.aws.amazon.com/federation", c.ConsoleFederationURL, "console_federation_url defaulted")
 	assert.Equal(t, "native", c.CredsStore, "creds_store was set")
 	assert.Equal(t, "Cognito OAuth Tokens", c.CredsOAuthKey, "creds_oauth_key_url was set")
 	assert.Equal(t, "Cognito AWS Credentials", c.CredsAwsKey, "creds_aws_key_url was set")
 	assert.Equal(t, 8080, c.ListenPort, "listen_port was set")
+	assert.Equal(t, 10*time.Minute, c.RefreshMargin, "refresh_margin was set")
+	assert.Equal(t, time.Minute, c.ClockSkew, "clock_skew was set")
+	assert.Equal(t, 11*time.Minute, c.ExpiryMargin(), "expiry margin was calculated")
+	assert.Equal(t, 12, c.PasswordPolicy.MinimumLength, "password_policy.minimum_length was set")
+	assert.True(t, c.PasswordPolicy.RequireLowercase, "password_policy.require_lowercase was set")
+	assert.True(t, c.PasswordPolicy.RequireUppercase, "password_policy.require_uppercase was set")
+	assert.True(t, c.PasswordPolicy.RequireNumbers, "password_policy.require_numbers was set")
+	assert.False(t, c.PasswordPolicy.RequireSymbols, "password_policy.require_symbols was set")
+
+	destination, err := c.ConsoleServiceDestination("ec2")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://console.aws.amazon.com/ec2/home", destination, "console_services was set")
+	_, err = c.ConsoleServiceDestination("s3")
+	assert.Error(t, err)
+
+	assert.Equal(t, "arn:aws:iam::111111111111:role/admin", c.ConsoleAccounts["production"], "console_accounts was set")
 }
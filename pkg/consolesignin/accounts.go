@@ -0,0 +1,53 @@
+package consolesignin
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	maxSessionNameLength = 64
+)
+
+// sessionNameInvalid matches characters which aren't allowed in a role session name.
+var sessionNameInvalid = regexp.MustCompile(`[^\w+=,.@-]`)
+
+// MatchAccounts returns the sorted account aliases which fuzzy match the filter.
+//
+// An alias matches if it contains the characters of the filter in order,
+// ignoring case, so "prd" matches "production".
+func MatchAccounts(accounts map[string]string, filter string) []string {
+	var matches []string
+	for alias := range accounts {
+		if fuzzyMatch(strings.ToLower(alias), strings.ToLower(filter)) {
+			matches = append(matches, alias)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// fuzzyMatch checks if the characters of the filter appear in order in the value.
+func fuzzyMatch(value string, filter string) bool {
+	for _, r := range filter {
+		i := strings.IndexRune(value, r)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(string(r)):]
+	}
+	return true
+}
+
+// SessionName converts a username into a valid role session name.
+func SessionName(username string) string {
+	name := sessionNameInvalid.ReplaceAllString(username, "-")
+	if len(name) > maxSessionNameLength {
+		name = name[:maxSessionNameLength]
+	}
+	if len(name) < 2 {
+		return "cognito-auth"
+	}
+	return name
+}
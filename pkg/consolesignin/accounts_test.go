@@ -0,0 +1,28 @@
+package consolesignin
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchAccounts(t *testing.T) {
+	accounts := map[string]string{
+		"production": "arn:aws:iam::111111111111:role/admin",
+		"staging":    "arn:aws:iam::222222222222:role/admin",
+		"dev":        "arn:aws:iam::333333333333:role/admin",
+	}
+
+	assert.Equal(t, []string{"dev", "production", "staging"}, MatchAccounts(accounts, ""))
+	assert.Equal(t, []string{"production"}, MatchAccounts(accounts, "prd"))
+	assert.Equal(t, []string{"production", "staging"}, MatchAccounts(accounts, "IN"))
+	assert.Empty(t, MatchAccounts(accounts, "test"))
+}
+
+func TestSessionName(t *testing.T) {
+	assert.Equal(t, "jsmith@example.com", SessionName("jsmith@example.com"))
+	assert.Equal(t, "John-Smith", SessionName("John Smith"))
+	assert.Equal(t, "cognito-auth", SessionName(""))
+	assert.Len(t, SessionName(strings.Repeat("a", 100)), 64)
+}
@@ -2,62 +2,99 @@ package consolesignin
 
 import (
 	"encoding/json"
-	"fmt"
 	"github.com/pkg/errors"
 	"github.com/skpr/cognito-auth/pkg/awscreds"
 	"github.com/skpr/cognito-auth/pkg/config"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"os"
+	"strings"
 )
 
+// CredentialsGetter gets the AWS credentials to sign in with.
+type CredentialsGetter interface {
+	GetAwsCredentials() (awscreds.Credentials, error)
+}
+
 // ConsoleSignin type
 type ConsoleSignin struct {
-	credentialsResolver awscreds.CredentialsResolver
-	cognitoConfig       config.Config
+	credentialsGetter CredentialsGetter
+	cognitoConfig     config.Config
+	httpClient        *http.Client
 }
 
-// New creates a new credentials resolver.
-func New(cognitoConfig *config.Config, resolver *awscreds.CredentialsResolver) *ConsoleSignin {
-	return &ConsoleSignin{
-		credentialsResolver: *resolver,
-		cognitoConfig:       *cognitoConfig,
+// New creates a new console signin.
+//
+// The federation endpoint is the console_federation_url from the config, or
+// the global endpoint if it isn't set.
+func New(cognitoConfig *config.Config, credentialsGetter CredentialsGetter, httpClient *http.Client) *ConsoleSignin {
+	signin := &ConsoleSignin{
+		credentialsGetter: credentialsGetter,
+		cognitoConfig:     *cognitoConfig,
+		httpClient:        httpClient,
 	}
+	if signin.cognitoConfig.ConsoleFederationURL == "" {
+		signin.cognitoConfig.ConsoleFederationURL = config.DefaultConsoleFederationURL
+	}
+	return signin
 }
 
 // GetSignInLink gets the federated console sign in link.
-func (c *ConsoleSignin) GetSignInLink() (string, error) {
+//
+// If the destination is empty, the console destination from the config is
+// used. The console session lasts as long as the credentials, as the
+// federation endpoint rejects a SessionDuration for role credentials.
+func (c *ConsoleSignin) GetSignInLink(destination string) (string, error) {
+	if destination == "" {
+		destination = c.cognitoConfig.ConsoleDestination
+	}
 
-	creds, err := c.credentialsResolver.GetAwsCredentials()
+	federationURL, err := url.Parse(c.cognitoConfig.ConsoleFederationURL)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed parsing console_federation_url")
+	}
+
+	creds, err := c.credentialsGetter.GetAwsCredentials()
 	if err != nil {
 		return "", errors.Wrap(err, "Failed getting credentials")
 	}
 
-	// Get console federated login link
-	federationURL := url.URL{
-		Scheme: "https",
-		Host:   "signin.aws.amazon.com",
-		Path:   "/federation",
+	signInToken, err := c.getSignInToken(*federationURL, creds)
+	if err != nil {
+		return "", err
 	}
 
+	query := federationURL.Query()
+	query.Add("Action", "login")
+	query.Add("Issuer", c.cognitoConfig.ConsoleIssuer)
+	query.Add("Destination", destination)
+	query.Add("SigninToken", signInToken)
+
+	federationURL.RawQuery = query.Encode()
+
+	return federationURL.String(), nil
+}
+
+// getSignInToken exchanges the credentials for a sign in token.
+func (c *ConsoleSignin) getSignInToken(federationURL url.URL, creds awscreds.Credentials) (string, error) {
 	sessionParams := map[string]string{
 		"sessionId":    creds.AccessKey,
 		"sessionKey":   creds.SecretAccessKey,
 		"sessionToken": creds.SessionToken,
 	}
-	jsonParams, _ := json.Marshal(sessionParams)
+	jsonParams, err := json.Marshal(sessionParams)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed marshalling session")
+	}
 
 	query := federationURL.Query()
 	query.Add("Action", "getSigninToken")
-	query.Add("SessionDuration", "43200")
 	query.Add("Session", string(jsonParams))
 	federationURL.RawQuery = query.Encode()
 
-	response, err := http.Get(federationURL.String())
+	response, err := c.httpClient.Get(federationURL.String())
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return "", errors.Wrap(err, "Failed getting sign in token")
 	}
 	defer response.Body.Close()
 	bodyBytes, err := ioutil.ReadAll(response.Body)
@@ -65,28 +102,20 @@ func (c *ConsoleSignin) GetSignInLink() (string, error) {
 		return "", errors.Wrap(err, "Failed getting response body")
 	}
 
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("Failed getting sign in token: %s: %s", response.Status, strings.TrimSpace(string(bodyBytes)))
+	}
+
 	data := map[string]string{}
 	err = json.Unmarshal(bodyBytes, &data)
 	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		return "", errors.Wrap(err, "Failed unmarshalling sign in token")
 	}
 
 	signInToken := data["SigninToken"]
-
-	federationURL = url.URL{
-		Scheme: "https",
-		Host:   "signin.aws.amazon.com",
-		Path:   "/federation",
+	if signInToken == "" {
+		return "", errors.New("not found: SigninToken")
 	}
 
-	query = federationURL.Query()
-	query.Add("Action", "login")
-	query.Add("Issuer", c.cognitoConfig.ConsoleIssuer)
-	query.Add("Destination", c.cognitoConfig.ConsoleDestination)
-	query.Add("SigninToken", signInToken)
-
-	federationURL.RawQuery = query.Encode()
-
-	return federationURL.String(), nil
+	return signInToken, nil
 }
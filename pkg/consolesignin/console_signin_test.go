@@ -0,0 +1,108 @@
+package consolesignin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/config"
+)
+
+type credentialsGetter struct {
+	err error
+}
+
+func (g credentialsGetter) GetAwsCredentials() (awscreds.Credentials, error) {
+	return awscreds.Credentials{
+		AccessKey:       "ABCDEFGHIJKLMNOP",
+		SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
+		SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU",
+	}, g.err
+}
+
+func TestGetSignInLink(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "getSigninToken", r.URL.Query().Get("Action"))
+		assert.Empty(t, r.URL.Query().Get("SessionDuration"), "role credentials can't set a session duration")
+
+		session := map[string]string{}
+		assert.Nil(t, json.Unmarshal([]byte(r.URL.Query().Get("Session")), &session))
+		assert.Equal(t, "ABCDEFGHIJKLMNOP", session["sessionId"])
+
+		_ = json.NewEncoder(w).Encode(map[string]string{"SigninToken": "TOKEN1234567890"})
+	}))
+	defer server.Close()
+
+	cognitoConfig := &config.Config{
+		ConsoleIssuer:        "example.com",
+		ConsoleDestination:   "https://console.aws.amazon.com/cloudwatch",
+		ConsoleFederationURL: server.URL + "/federation",
+	}
+	signin := New(cognitoConfig, credentialsGetter{}, server.Client())
+
+	link, err := signin.GetSignInLink("")
+	assert.Nil(t, err)
+
+	linkURL, err := url.Parse(link)
+	assert.Nil(t, err)
+	assert.Equal(t, "/federation", linkURL.Path)
+	assert.Equal(t, "login", linkURL.Query().Get("Action"))
+	assert.Equal(t, "example.com", linkURL.Query().Get("Issuer"))
+	assert.Equal(t, "https://console.aws.amazon.com/cloudwatch", linkURL.Query().Get("Destination"))
+	assert.Equal(t, "TOKEN1234567890", linkURL.Query().Get("SigninToken"))
+
+	link, err = signin.GetSignInLink("https://console.aws.amazon.com/ec2/home")
+	assert.Nil(t, err)
+	linkURL, err = url.Parse(link)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://console.aws.amazon.com/ec2/home", linkURL.Query().Get("Destination"))
+}
+
+func TestGetSignInLinkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	cognitoConfig := &config.Config{
+		ConsoleFederationURL: server.URL + "/federation",
+	}
+
+	_, err := New(cognitoConfig, credentialsGetter{}, server.Client()).GetSignInLink("")
+	assert.Error(t, err)
+
+	_, err = New(cognitoConfig, credentialsGetter{err: errors.New("expired")}, server.Client()).GetSignInLink("")
+	assert.EqualError(t, err, "Failed getting credentials: expired")
+}
+
+// roundTripper handles requests without a server.
+type roundTripper func(r *http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestGetSignInLinkDefaultFederationURL(t *testing.T) {
+	httpClient := &http.Client{
+		Transport: roundTripper(func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "https://signin.aws.amazon.com/federation", r.URL.Scheme+"://"+r.URL.Host+r.URL.Path)
+			recorder := httptest.NewRecorder()
+			_ = json.NewEncoder(recorder).Encode(map[string]string{"SigninToken": "TOKEN1234567890"})
+			return recorder.Result(), nil
+		}),
+	}
+
+	cognitoConfig := &config.Config{
+		ConsoleIssuer:      "example.com",
+		ConsoleDestination: "https://console.aws.amazon.com/cloudwatch",
+	}
+	link, err := New(cognitoConfig, credentialsGetter{}, httpClient).GetSignInLink("")
+	assert.Nil(t, err)
+	assert.Contains(t, link, "https://signin.aws.amazon.com/federation?Action=login")
+}
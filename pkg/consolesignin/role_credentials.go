@@ -0,0 +1,84 @@
+package consolesignin
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+)
+
+// STSFactory creates an STS client which uses the credentials.
+type STSFactory func(creds awscreds.Credentials) stsiface.STSAPI
+
+// NewSTSFactory creates an STS factory for clients from the session.
+func NewSTSFactory(sess client.ConfigProvider) STSFactory {
+	return func(creds awscreds.Credentials) stsiface.STSAPI {
+		stsConfig := aws.NewConfig().WithCredentials(credentials.NewStaticCredentials(creds.AccessKey, creds.SecretAccessKey, creds.SessionToken))
+		return sts.New(sess, stsConfig)
+	}
+}
+
+// RoleCredentials gets the credentials for a role, assumed through STS with
+// the credentials from the getter.
+type RoleCredentials struct {
+	credentialsGetter CredentialsGetter
+	newSTS            STSFactory
+	roleARN           string
+	sessionName       string
+	duration          time.Duration
+}
+
+const (
+	minRoleDuration = 15 * time.Minute
+	maxRoleDuration = 12 * time.Hour
+)
+
+// NewRoleCredentials creates new role credentials.
+//
+// If the duration is zero, the role's default session duration is used,
+// otherwise it must be between 15 minutes and 12 hours, and no longer than
+// the role's maximum session duration.
+func NewRoleCredentials(credentialsGetter CredentialsGetter, newSTS STSFactory, roleARN string, sessionName string, duration time.Duration) *RoleCredentials {
+	return &RoleCredentials{
+		credentialsGetter: credentialsGetter,
+		newSTS:            newSTS,
+		roleARN:           roleARN,
+		sessionName:       sessionName,
+		duration:          duration,
+	}
+}
+
+// GetAwsCredentials assumes the role, returning its credentials.
+func (r *RoleCredentials) GetAwsCredentials() (awscreds.Credentials, error) {
+	if r.duration != 0 && (r.duration < minRoleDuration || r.duration > maxRoleDuration) {
+		return awscreds.Credentials{}, errors.Errorf("session duration must be between %s and %s", minRoleDuration, maxRoleDuration)
+	}
+
+	creds, err := r.credentialsGetter.GetAwsCredentials()
+	if err != nil {
+		return awscreds.Credentials{}, err
+	}
+
+	input := new(sts.AssumeRoleInput)
+	input.SetRoleArn(r.roleARN)
+	input.SetRoleSessionName(r.sessionName)
+	if r.duration != 0 {
+		input.SetDurationSeconds(int64(r.duration.Seconds()))
+	}
+	output, err := r.newSTS(creds).AssumeRole(input)
+	if err != nil {
+		return awscreds.Credentials{}, errors.Wrapf(err, "Failed to assume role %s", r.roleARN)
+	}
+
+	return awscreds.Credentials{
+		AccessKey:       *output.Credentials.AccessKeyId,
+		SecretAccessKey: *output.Credentials.SecretAccessKey,
+		SessionToken:    *output.Credentials.SessionToken,
+		Expiry:          *output.Credentials.Expiration,
+	}, nil
+}
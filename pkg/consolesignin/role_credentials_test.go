@@ -0,0 +1,128 @@
+package consolesignin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+)
+
+// fakeSTS records the assume role request, and returns the configured response.
+//
+// Methods which aren't overridden panic, through the nil embedded interface.
+type fakeSTS struct {
+	stsiface.STSAPI
+
+	creds            awscreds.Credentials
+	assumeRoleInput  *sts.AssumeRoleInput
+	assumeRoleOutput *sts.AssumeRoleOutput
+	assumeRoleErr    error
+}
+
+func (f *fakeSTS) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	f.assumeRoleInput = input
+	return f.assumeRoleOutput, f.assumeRoleErr
+}
+
+func (f *fakeSTS) factory(creds awscreds.Credentials) stsiface.STSAPI {
+	f.creds = creds
+	return f
+}
+
+func TestRoleCredentials(t *testing.T) {
+	expiry := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	roleARN := "arn:aws:iam::111111111111:role/admin"
+
+	tests := []struct {
+		name             string
+		getter           credentialsGetter
+		duration         time.Duration
+		sts              *fakeSTS
+		expectedDuration *int64
+		expected         awscreds.Credentials
+		expectedErr      string
+	}{
+		{
+			name: "assumes role",
+			sts: &fakeSTS{
+				assumeRoleOutput: &sts.AssumeRoleOutput{
+					Credentials: &sts.Credentials{
+						AccessKeyId:     aws.String("ROLEACCESSKEY"),
+						SecretAccessKey: aws.String("ROLESECRET"),
+						SessionToken:    aws.String("ROLETOKEN"),
+						Expiration:      aws.Time(expiry),
+					},
+				},
+			},
+			expected: awscreds.Credentials{
+				AccessKey:       "ROLEACCESSKEY",
+				SecretAccessKey: "ROLESECRET",
+				SessionToken:    "ROLETOKEN",
+				Expiry:          expiry,
+			},
+		},
+		{
+			name:     "sets duration",
+			duration: 4 * time.Hour,
+			sts: &fakeSTS{
+				assumeRoleOutput: &sts.AssumeRoleOutput{
+					Credentials: &sts.Credentials{
+						AccessKeyId:     aws.String("ROLEACCESSKEY"),
+						SecretAccessKey: aws.String("ROLESECRET"),
+						SessionToken:    aws.String("ROLETOKEN"),
+						Expiration:      aws.Time(expiry),
+					},
+				},
+			},
+			expectedDuration: aws.Int64(14400),
+			expected: awscreds.Credentials{
+				AccessKey:       "ROLEACCESSKEY",
+				SecretAccessKey: "ROLESECRET",
+				SessionToken:    "ROLETOKEN",
+				Expiry:          expiry,
+			},
+		},
+		{
+			name:        "invalid duration",
+			duration:    24 * time.Hour,
+			sts:         &fakeSTS{},
+			expectedErr: "session duration must be between 15m0s and 12h0m0s",
+		},
+		{
+			name:        "credentials error",
+			getter:      credentialsGetter{err: errors.New("expired")},
+			sts:         &fakeSTS{},
+			expectedErr: "expired",
+		},
+		{
+			name:        "assume role error",
+			sts:         &fakeSTS{assumeRoleErr: errors.New("AccessDenied")},
+			expectedErr: "Failed to assume role arn:aws:iam::111111111111:role/admin: AccessDenied",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roleCredentials := NewRoleCredentials(test.getter, test.sts.factory, roleARN, "jsmith", test.duration)
+
+			creds, err := roleCredentials.GetAwsCredentials()
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, creds)
+
+			assert.Equal(t, "ABCDEFGHIJKLMNOP", test.sts.creds.AccessKey, "STS client uses the Cognito credentials")
+			assert.Equal(t, roleARN, aws.StringValue(test.sts.assumeRoleInput.RoleArn))
+			assert.Equal(t, "jsmith", aws.StringValue(test.sts.assumeRoleInput.RoleSessionName))
+			assert.Equal(t, test.expectedDuration, test.sts.assumeRoleInput.DurationSeconds)
+		})
+	}
+}
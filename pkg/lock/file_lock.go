@@ -0,0 +1,74 @@
+//go:build !windows
+// +build !windows
+
+package lock
+
+import (
+	"os"
+	"path"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+)
+
+// FileLock is an exclusive flock on a lock file, shared between processes.
+//
+// It is also safe to share between goroutines, as the mutex is held for as
+// long as the flock is.
+type FileLock struct {
+	filename string
+	mutex    sync.Mutex
+	file     *os.File
+}
+
+// NewFileLock creates a new file lock.
+func NewFileLock(filename string) *FileLock {
+	return &FileLock{
+		filename: filename,
+	}
+}
+
+// Lock blocks until the lock is acquired.
+func (l *FileLock) Lock() error {
+	l.mutex.Lock()
+
+	err := os.MkdirAll(path.Dir(l.filename), 0700)
+	if err != nil {
+		l.mutex.Unlock()
+		return errors.Wrap(err, "Failed to create lock directory")
+	}
+
+	file, err := os.OpenFile(l.filename, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		l.mutex.Unlock()
+		return errors.Wrap(err, "Failed to open lock file")
+	}
+
+	err = syscall.Flock(int(file.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		file.Close()
+		l.mutex.Unlock()
+		return errors.Wrap(err, "Failed to lock")
+	}
+
+	l.file = file
+	return nil
+}
+
+// Unlock releases the lock.
+func (l *FileLock) Unlock() error {
+	if l.file == nil {
+		return nil
+	}
+	file := l.file
+	l.file = nil
+	defer l.mutex.Unlock()
+	defer file.Close()
+
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+	if err != nil {
+		return errors.Wrap(err, "Failed to unlock")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+//go:build !windows
+// +build !windows
+
+package lock
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "cache", "test.lock")
+	first := NewFileLock(filename)
+	second := NewFileLock(filename)
+
+	assert.Nil(t, first.Lock())
+
+	locked := make(chan bool)
+	go func() {
+		assert.Nil(t, second.Lock())
+		locked <- true
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("second lock was acquired while the first was held")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.Nil(t, first.Unlock())
+
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("second lock was not acquired after the first was released")
+	}
+
+	assert.Nil(t, second.Unlock())
+}
+
+func TestFileLockSharedBetweenGoroutines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lock")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	l := NewFileLock(filepath.Join(dir, "test.lock"))
+
+	var wg sync.WaitGroup
+	var holders int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.Nil(t, l.Lock())
+				assert.Equal(t, int32(1), atomic.AddInt32(&holders, 1), "lock was only held by one goroutine")
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&holders, -1)
+				assert.Nil(t, l.Unlock())
+			}
+		}()
+	}
+	wg.Wait()
+}
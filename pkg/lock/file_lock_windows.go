@@ -0,0 +1,11 @@
+package lock
+
+// FileLock doesn't lock on Windows, where flock isn't available.
+type FileLock struct {
+	Nop
+}
+
+// NewFileLock creates a new file lock.
+func NewFileLock(filename string) *FileLock {
+	return &FileLock{}
+}
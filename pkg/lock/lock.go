@@ -0,0 +1,21 @@
+package lock
+
+// Locker is held around the read-refresh-write of a cache, so concurrent
+// processes wait for a single refresh instead of all refreshing at once.
+type Locker interface {
+	Lock() error
+	Unlock() error
+}
+
+// Nop is a locker which doesn't lock, for caches which aren't shared between processes.
+type Nop struct{}
+
+// Lock does nothing.
+func (Nop) Lock() error {
+	return nil
+}
+
+// Unlock does nothing.
+func (Nop) Unlock() error {
+	return nil
+}
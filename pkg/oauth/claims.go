@@ -0,0 +1,41 @@
+package oauth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Claims type
+type Claims struct {
+	Subject  string `json:"sub"`
+	Username string `json:"cognito:username"`
+	Email    string `json:"email"`
+}
+
+// ParseClaims decodes the claims from the ID token payload.
+//
+// The signature is not verified, so the claims must only be used for display
+// and request parameters, never for authorisation decisions.
+func ParseClaims(idToken string) (Claims, error) {
+	var claims Claims
+
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return Claims{}, errors.New("malformed id_token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return Claims{}, errors.Wrap(err, "failed to decode id_token")
+	}
+
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return Claims{}, errors.Wrap(err, "failed to unmarshal id_token claims")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,32 @@
+package oauth
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// ErrNoRefreshToken is returned when the tokens have expired, and there is no
+// refresh token to refresh them with.
+var ErrNoRefreshToken = errors.New("not found: refresh_token")
+
+// IsLoginRequired checks if the error means the user needs to log in again,
+// because there are no cached tokens, or the refresh token has expired or been
+// revoked. Other errors, such as network failures, may succeed on retry.
+func IsLoginRequired(err error) bool {
+	cause := errors.Cause(err)
+	if cause == ErrNoRefreshToken || os.IsNotExist(cause) {
+		return true
+	}
+	if awsErr, ok := cause.(awserr.Error); ok {
+		return awsErr.Code() == cognitoidentityprovider.ErrCodeNotAuthorizedException
+	}
+	if retrieveErr, ok := cause.(*oauth2.RetrieveError); ok {
+		return strings.Contains(string(retrieveErr.Body), "invalid_grant")
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package oauth
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestIsLoginRequired(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "no refresh token", err: errors.Wrap(ErrNoRefreshToken, "Failed to refresh tokens"), expected: true},
+		{name: "no cached tokens", err: errors.Wrap(os.ErrNotExist, "failed to load tokens"), expected: true},
+		{name: "refresh token expired", err: errors.Wrap(awserr.New(cognitoidentityprovider.ErrCodeNotAuthorizedException, "Refresh Token has expired", nil), "Failed to refresh tokens"), expected: true},
+		{name: "oidc refresh token expired", err: &oauth2.RetrieveError{Response: &http.Response{Status: "400 Bad Request"}, Body: []byte(`{"error":"invalid_grant"}`)}, expected: true},
+		{name: "oidc server error", err: &oauth2.RetrieveError{Response: &http.Response{Status: "503 Service Unavailable"}, Body: []byte("unavailable")}},
+		{name: "throttled", err: awserr.New(cognitoidentityprovider.ErrCodeTooManyRequestsException, "Rate exceeded", nil)},
+		{name: "network error", err: errors.New("dial tcp: i/o timeout")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsLoginRequired(test.err))
+		})
+	}
+}
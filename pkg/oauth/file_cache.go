@@ -2,15 +2,16 @@ package oauth
 
 import (
 	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/lock"
+	"github.com/skpr/cognito-auth/pkg/securefile"
 	"gopkg.in/yaml.v2"
-	"io/ioutil"
 	"os"
-	"path"
 )
 
 // Constants
 const (
-	filename = "oauth_tokens.yml"
+	filename     = "oauth_tokens.yml"
+	lockFilename = "oauth_tokens.lock"
 )
 
 // FileCache handles caching oauth2 tokens.
@@ -36,7 +37,7 @@ func (c *FileCache) Get() (Tokens, error) {
 		return Tokens{}, errors.Wrap(err, "failed to load tokens")
 	}
 
-	data, err := ioutil.ReadFile(c.cacheFile)
+	data, err := securefile.ReadFile(c.cacheFile)
 	if err != nil {
 		return Tokens{}, errors.Wrap(err, "failed to read tokens")
 	}
@@ -57,21 +58,12 @@ func (c *FileCache) Get() (Tokens, error) {
 // Put writes an oauth token to cache.
 func (c *FileCache) Put(token Tokens) error {
 
-	// Create parent directory if it doesn't exist.
-	if _, err := os.Stat(c.cacheFile); os.IsNotExist(err) {
-		dir := path.Dir(c.cacheFile)
-		err := os.MkdirAll(dir, os.ModePerm)
-		if err != nil {
-			return errors.Wrap(err, "Failed to create directory")
-		}
-	}
-
 	data, err := yaml.Marshal(&token)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal tokens")
 	}
 
-	err = ioutil.WriteFile(c.cacheFile, data, 0644)
+	err = securefile.WriteFile(c.cacheFile, data)
 	if err != nil {
 		return errors.Wrap(err, "failed to write tokens")
 	}
@@ -87,3 +79,11 @@ func (c *FileCache) Delete(token Tokens) error {
 	}
 	return nil
 }
+
+// NewCacheLock creates the lock held while refreshing the tokens cache.
+//
+// The lock lives in the cache directory for all creds stores, so that
+// refreshes of the keychain are also serialised.
+func NewCacheLock(cacheDir string) *lock.FileLock {
+	return lock.NewFileLock(cacheDir + "/" + lockFilename)
+}
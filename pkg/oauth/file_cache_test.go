@@ -2,6 +2,8 @@ package oauth
 
 import (
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
 	"testing"
 	"time"
 )
@@ -15,10 +17,15 @@ func TestTokensCache(t *testing.T) {
 		RefreshToken: "ABCDEFGHIJKLMNOP",
 		IDToken:      "0123456789ABCDEF",
 		Expiry:       expiry,
+		Session:      SessionOIDC,
 	}
 
-	tokensCache := NewFileCache("/tmp/skpr/oauth.yml")
-	err := tokensCache.Put(tokens)
+	dir, err := ioutil.TempDir("", "oauth")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	tokensCache := NewFileCache(dir)
+	err = tokensCache.Put(tokens)
 	assert.Nil(t, err)
 
 	tokens, err = tokensCache.Get()
@@ -28,6 +35,7 @@ func TestTokensCache(t *testing.T) {
 	assert.Equal(t, "ABCDEFGHIJKLMNOP", tokens.RefreshToken, "refresh_token was set")
 	assert.Equal(t, "0123456789ABCDEF", tokens.IDToken, "id_token was set")
 	assert.Equal(t, expiry, tokens.Expiry, "expiry was set")
+	assert.Equal(t, SessionOIDC, tokens.Session, "session was set")
 }
 
 func TestHasExpired(t *testing.T) {
@@ -39,5 +47,19 @@ func TestHasExpired(t *testing.T) {
 		Expiry:       expiry,
 	}
 
-	assert.True(t, tokens.HasExpired())
+	assert.True(t, tokens.HasExpired(0))
+
+	tokens.Expiry = time.Now().UTC().Add(60 * time.Second)
+	assert.False(t, tokens.HasExpired(0))
+	assert.True(t, tokens.HasExpired(300*time.Second))
+
+	// The margin is capped at half the lifetime, which is 2m30s here.
+	now := time.Now().UTC()
+	tokens.Issued = now.Add(-time.Minute)
+	tokens.Expiry = now.Add(4 * time.Minute)
+	assert.False(t, tokens.HasExpired(330*time.Second))
+	assert.Equal(t, tokens.Expiry.Add(-150*time.Second), tokens.RefreshAt(330*time.Second))
+	tokens.Issued = now.Add(-3 * time.Minute)
+	tokens.Expiry = now.Add(2 * time.Minute)
+	assert.True(t, tokens.HasExpired(330*time.Second))
 }
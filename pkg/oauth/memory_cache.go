@@ -0,0 +1,64 @@
+package oauth
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// MemoryCache handles caching oauth2 tokens in memory, for embedding without
+// touching the disk or keychain. It is safe for concurrent use.
+type MemoryCache struct {
+	mutex        sync.RWMutex
+	tokens       *Tokens
+	evictExpired bool
+}
+
+// NewMemoryCache creates a new memory cache.
+//
+// If evictExpired is set, tokens are evicted once they expire. This evicts the
+// refresh token too, so a new login is required instead of a refresh.
+func NewMemoryCache(evictExpired bool) *MemoryCache {
+	return &MemoryCache{
+		evictExpired: evictExpired,
+	}
+}
+
+// Get gets the tokens from memory.
+func (c *MemoryCache) Get() (Tokens, error) {
+	c.mutex.RLock()
+	tokens := c.tokens
+	c.mutex.RUnlock()
+
+	if tokens == nil {
+		return Tokens{}, errors.New("not found: tokens")
+	}
+
+	if c.evictExpired && tokens.HasExpired(0) {
+		c.mutex.Lock()
+		// Only evict if the tokens weren't replaced in the meantime.
+		if c.tokens == tokens {
+			c.tokens = nil
+		}
+		c.mutex.Unlock()
+		return Tokens{}, errors.New("not found: tokens have expired")
+	}
+
+	return *tokens, nil
+}
+
+// Put puts the tokens in memory.
+func (c *MemoryCache) Put(token Tokens) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tokens = &token
+	return nil
+}
+
+// Delete deletes the tokens from memory.
+func (c *MemoryCache) Delete(token Tokens) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.tokens = nil
+	return nil
+}
@@ -0,0 +1,76 @@
+package oauth
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache(false)
+
+	_, err := cache.Get()
+	assert.Error(t, err)
+
+	tokens := Tokens{
+		AccessToken:  "ABCDEFGHIJKLMNOP1234567890",
+		RefreshToken: "ABCDEFGHIJKLMNOP",
+		IDToken:      "0123456789ABCDEF",
+		Expiry:       time.Now().Add(-300 * time.Second),
+	}
+	assert.Nil(t, cache.Put(tokens))
+
+	// Expired tokens are kept, so they can be refreshed.
+	cached, err := cache.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, tokens, cached)
+
+	assert.Nil(t, cache.Delete(tokens))
+	_, err = cache.Get()
+	assert.Error(t, err)
+}
+
+func TestMemoryCacheEvictExpired(t *testing.T) {
+	cache := NewMemoryCache(true)
+
+	tokens := Tokens{
+		AccessToken:  "ABCDEFGHIJKLMNOP1234567890",
+		RefreshToken: "ABCDEFGHIJKLMNOP",
+		IDToken:      "0123456789ABCDEF",
+		Expiry:       time.Now().Add(300 * time.Second),
+	}
+	assert.Nil(t, cache.Put(tokens))
+
+	_, err := cache.Get()
+	assert.Nil(t, err)
+
+	tokens.Expiry = time.Now().Add(-300 * time.Second)
+	assert.Nil(t, cache.Put(tokens))
+
+	_, err = cache.Get()
+	assert.Error(t, err)
+}
+
+func TestMemoryCacheConcurrent(t *testing.T) {
+	cache := NewMemoryCache(true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tokens := Tokens{
+				AccessToken:  "ABCDEFGHIJKLMNOP1234567890",
+				RefreshToken: "ABCDEFGHIJKLMNOP",
+				IDToken:      "0123456789ABCDEF",
+				Expiry:       time.Now().Add(300 * time.Second),
+			}
+			assert.Nil(t, cache.Put(tokens))
+			_, err := cache.Get()
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,56 @@
+package oauth
+
+import (
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/secrets"
+	"gopkg.in/yaml.v2"
+)
+
+// SecretStoreCache handles caching oauth2 tokens in a secret store.
+type SecretStoreCache struct {
+	store secrets.SecretStore
+}
+
+// NewSecretStoreCache creates a new secret store cache.
+func NewSecretStoreCache(store secrets.SecretStore) *SecretStoreCache {
+	return &SecretStoreCache{
+		store: store,
+	}
+}
+
+// Get gets the tokens from the secret store.
+func (k SecretStoreCache) Get() (Tokens, error) {
+	var tokens Tokens
+
+	data, err := k.store.Get()
+	if err != nil {
+		return Tokens{}, errors.Wrap(err, "failed to get tokens")
+	}
+
+	err = yaml.Unmarshal([]byte(data), &tokens)
+	if err != nil {
+		return Tokens{}, errors.Wrap(err, "failed to unmarshal tokens")
+	}
+
+	return tokens, nil
+}
+
+// Put puts the tokens in the secret store.
+func (k SecretStoreCache) Put(token Tokens) error {
+	data, err := yaml.Marshal(&token)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal tokens")
+	}
+	if err := k.store.Put(string(data)); err != nil {
+		return errors.Wrap(err, "failed to put tokens")
+	}
+	return nil
+}
+
+// Delete deletes the tokens from the secret store.
+func (k SecretStoreCache) Delete(token Tokens) error {
+	if err := k.store.Delete(); err != nil {
+		return errors.Wrap(err, "failed to delete tokens")
+	}
+	return nil
+}
@@ -2,15 +2,38 @@ package oauth
 
 import (
 	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/config"
 	"time"
 )
 
+// Session types, which determine how tokens are refreshed.
+const (
+	SessionUserPool = "userpool"
+	SessionOIDC     = "oidc"
+)
+
 // Tokens type
 type Tokens struct {
 	AccessToken  string    `yaml:"access_token"`
 	RefreshToken string    `yaml:"refresh_token"`
 	IDToken      string    `yaml:"id_token"`
 	Expiry       time.Time `yaml:"expiry"`
+	Issued       time.Time `yaml:"issued,omitempty"`
+	Session      string    `yaml:"session,omitempty"`
+}
+
+// SessionType returns the session type the tokens were issued by.
+//
+// Tokens cached before the session type was recorded are from an OIDC login
+// if the config has a token URL, otherwise from a user pool login.
+func SessionType(tokens Tokens, cognitoConfig *config.Config) string {
+	if tokens.Session != "" {
+		return tokens.Session
+	}
+	if cognitoConfig.TokenURL != "" {
+		return SessionOIDC
+	}
+	return SessionUserPool
 }
 
 // Validate the OAuth token file.
@@ -30,7 +53,19 @@ func (c *Tokens) Validate() error {
 	return nil
 }
 
-// HasExpired checks if the token has expired.
-func (c *Tokens) HasExpired() bool {
-	return c.Expiry.Before(time.Now())
+// HasExpired checks if the token has expired, or will expire within the margin.
+func (c *Tokens) HasExpired(margin time.Duration) bool {
+	return c.RefreshAt(margin).Before(time.Now())
+}
+
+// RefreshAt returns when the tokens should be refreshed, which is the expiry
+// less the margin.
+//
+// The margin is capped at half the lifetime of the tokens, so tokens which
+// don't live much longer than the margin aren't refreshed on every call.
+func (c *Tokens) RefreshAt(margin time.Duration) time.Time {
+	if !c.Issued.IsZero() && margin > c.Expiry.Sub(c.Issued)/2 {
+		margin = c.Expiry.Sub(c.Issued) / 2
+	}
+	return c.Expiry.Add(-margin)
 }
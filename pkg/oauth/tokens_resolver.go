@@ -1,30 +1,51 @@
 package oauth
 
-import "github.com/pkg/errors"
+import (
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/lock"
+	"time"
+)
 
 // TokensResolver struct
 type TokensResolver struct {
 	tokensCache     TokenCache
 	tokensRefresher TokensRefresher
+	expiryMargin    time.Duration
+	locker          lock.Locker
 }
 
 // NewTokensResolver creates a new tokens resolver.
-func NewTokensResolver(tokensCache TokenCache, tokensRefresher TokensRefresher) *TokensResolver {
+//
+// Tokens are refreshed when they expire within the expiry margin, so callers
+// don't receive tokens which expire part way through their use. The locker is
+// held while refreshing, so concurrent processes only refresh once.
+func NewTokensResolver(tokensCache TokenCache, tokensRefresher TokensRefresher, expiryMargin time.Duration, locker lock.Locker) *TokensResolver {
 	return &TokensResolver{
 		tokensCache:     tokensCache,
 		tokensRefresher: tokensRefresher,
+		expiryMargin:    expiryMargin,
+		locker:          locker,
 	}
 }
 
 // GetTokens gets the tokens, refreshing if needed.
 func (r *TokensResolver) GetTokens() (Tokens, error) {
+	err := r.locker.Lock()
+	if err != nil {
+		return Tokens{}, errors.Wrap(err, "Failed to lock tokens cache")
+	}
+	defer r.locker.Unlock()
+
 	tokens, err := r.tokensCache.Get()
 	if err != nil {
 		return Tokens{}, errors.Wrap(err, "Failed to get tokens from cache")
 	}
-	if !tokens.HasExpired() {
+	if !tokens.HasExpired(r.expiryMargin) {
 		return tokens, nil
 	}
+	if tokens.RefreshToken == "" {
+		return Tokens{}, ErrNoRefreshToken
+	}
 	tokens, err = r.tokensRefresher.RefreshOAuthTokens(tokens.RefreshToken)
 	if err != nil {
 		return Tokens{}, errors.Wrap(err, "Failed to refresh tokens")
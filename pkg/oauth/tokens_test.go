@@ -0,0 +1,32 @@
+package oauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/config"
+)
+
+func TestSessionType(t *testing.T) {
+	userPoolConfig := &config.Config{}
+	oidcConfig := &config.Config{TokenURL: "https://example.auth.ap-southeast-2.amazoncognito.com/oauth2/token"}
+
+	tests := []struct {
+		name          string
+		tokens        Tokens
+		cognitoConfig *config.Config
+		expected      string
+	}{
+		{name: "oidc", tokens: Tokens{Session: SessionOIDC}, cognitoConfig: userPoolConfig, expected: SessionOIDC},
+		{name: "userpool", tokens: Tokens{Session: SessionUserPool}, cognitoConfig: oidcConfig, expected: SessionUserPool},
+		{name: "unrecorded with token url", tokens: Tokens{}, cognitoConfig: oidcConfig, expected: SessionOIDC},
+		{name: "unrecorded without token url", tokens: Tokens{}, cognitoConfig: userPoolConfig, expected: SessionUserPool},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SessionType(test.tokens, test.cognitoConfig))
+		})
+	}
+}
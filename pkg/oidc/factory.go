@@ -6,32 +6,26 @@ import (
 	"github.com/aws/aws-sdk-go/service/cognitoidentity"
 
 	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/cache"
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/oauth"
-	"github.com/skpr/cognito-auth/pkg/secrets"
 )
 
-// CreateLoginHandlerFileCache creates a login handler with a file cache.
-func CreateLoginHandlerFileCache(cognitoConfig *config.Config, sess *session.Session, cacheDir string) *LoginHandler {
-	tokensFileCache := oauth.NewFileCache(cacheDir)
-	awscredsFileCache := awscreds.NewFileCache(cacheDir)
-	return CreateLoginHandler(cognitoConfig, sess, tokensFileCache, awscredsFileCache)
-}
-
-// CreateLoginHandlerKeychainCache creates a login handler with a keychain cache.
-func CreateLoginHandlerKeychainCache(cognitoConfig *config.Config, sess *session.Session, username string) *LoginHandler {
-	oauthKeychain := secrets.NewKeychain(cognitoConfig.CredsOAuthKey, username)
-	oauthKeychainCache := oauth.NewKeychainCache(oauthKeychain)
-	awscredsKeychain := secrets.NewKeychain(cognitoConfig.CredsAwsKey, username)
-	awscredsKeychainCache := awscreds.NewKeychainCache(awscredsKeychain)
-	return CreateLoginHandler(cognitoConfig, sess, oauthKeychainCache, awscredsKeychainCache)
+// CreateLoginHandlerConfiguredCache creates a login handler with a cache in
+// the configured creds store.
+func CreateLoginHandlerConfiguredCache(cognitoConfig *config.Config, sess *session.Session, cacheDir string) (*LoginHandler, error) {
+	tokenCache, awscredsCache, err := cache.New(cognitoConfig, cacheDir)
+	if err != nil {
+		return nil, err
+	}
+	return CreateLoginHandler(cognitoConfig, sess, tokenCache, awscredsCache, cacheDir), nil
 }
 
 // CreateLoginHandler creates a login handler.
-func CreateLoginHandler(cognitoConfig *config.Config,  sess client.ConfigProvider, tokenCache oauth.TokenCache, awscredsCache awscreds.CredentialsCache) *LoginHandler {
+func CreateLoginHandler(cognitoConfig *config.Config,  sess client.ConfigProvider, tokenCache oauth.TokenCache, awscredsCache awscreds.CredentialsCache, cacheDir string) *LoginHandler {
 	tokensRefresher := NewTokensRefresher(cognitoConfig, tokenCache)
-	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher)
+	tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), oauth.NewCacheLock(cacheDir))
 	cognitoIdentity := cognitoidentity.New(sess)
-	credentialsResolver := awscreds.NewCredentialsResolver(cognitoConfig, awscredsCache, tokensResolver, cognitoIdentity)
+	credentialsResolver := awscreds.NewCredentialsResolver(cognitoConfig, awscredsCache, tokensResolver, cognitoIdentity, awscreds.NewCacheLock(cacheDir))
 	return  NewLoginHandler(cognitoConfig, tokenCache, credentialsResolver)
 }
@@ -3,7 +3,9 @@ package oidc
 import (
 	"context"
 	"fmt"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
@@ -63,7 +65,7 @@ type LoginHandler struct {
 	cognitoConfig       config.Config
 	oauth2Config        oauth2.Config
 	tokensCache         oauth.TokenCache
-	credentialsResolver awscreds.CredentialsResolver
+	credentialsResolver *awscreds.CredentialsResolver
 }
 
 // NewLoginHandler creates a new login handler
@@ -84,7 +86,7 @@ func NewLoginHandler(config *config.Config, tokensCache oauth.TokenCache, creden
 			Endpoint:     endpoint,
 		},
 		tokensCache:         tokensCache,
-		credentialsResolver: *credentialsResolver,
+		credentialsResolver: credentialsResolver,
 	}
 }
 
@@ -124,7 +126,7 @@ func (l *LoginHandler) getCode() (string, string, error) {
 	server := &http.Server{Addr: ":" + strconv.Itoa(l.cognitoConfig.ListenPort), Handler: handler}
 	go func() {
 		<-ctx.Done()
-		fmt.Println("Shutting down the HTTP server...")
+		fmt.Fprintln(os.Stderr, "Shutting down the HTTP server...")
 		_ = server.Shutdown(ctx)
 	}()
 	err := server.ListenAndServe()
@@ -134,8 +136,14 @@ func (l *LoginHandler) getCode() (string, string, error) {
 	return code, state, nil
 }
 
+// Tokens gets the tokens saved by the last login.
+func (l *LoginHandler) Tokens() (oauth.Tokens, error) {
+	return l.tokensCache.Get()
+}
+
 // Login logs in a user with the authorization code.
 func (l *LoginHandler) Login(code string) (awscreds.Credentials, error) {
+	issued := time.Now()
 	token, err := l.oauth2Config.Exchange(context.Background(), code)
 	if err != nil {
 		return awscreds.Credentials{}, errors.Wrap(err, "Failed to login with code")
@@ -143,14 +151,16 @@ func (l *LoginHandler) Login(code string) (awscreds.Credentials, error) {
 	// Extract the ID Token from OAuth2 token.
 	idToken, ok := token.Extra("id_token").(string)
 	if !ok {
-		return awscreds.Credentials{}, errors.Wrap(err, "Missing id_token")
+		return awscreds.Credentials{}, errors.New("Missing id_token")
 	}
 
 	tokens := oauth.Tokens{
 		AccessToken:  token.AccessToken,
 		RefreshToken: token.RefreshToken,
 		Expiry:       token.Expiry,
+		Issued:       issued,
 		IDToken:      idToken,
+		Session:      oauth.SessionOIDC,
 	}
 
 	err = l.tokensCache.Put(tokens)
@@ -6,8 +6,8 @@ import (
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/oauth"
 	"golang.org/x/oauth2"
-	"log"
 	"strconv"
+	"time"
 )
 
 // TokensRefresher struct
@@ -46,22 +46,25 @@ func (r *TokensRefresher) RefreshOAuthTokens(refreshToken string) (oauth.Tokens,
 		RefreshToken: refreshToken,
 	}
 	tokenSource := r.oidcConfig.TokenSource(context.Background(), &token)
+	issued := time.Now()
 	newToken, err := tokenSource.Token()
 	if err != nil {
-		log.Fatalln(err)
+		return oauth.Tokens{}, errors.Wrap(err, "Failed to refresh oauth2 tokens")
 	}
 
 	// Extract the ID Token from OAuth2 token.
 	idToken, ok := newToken.Extra("id_token").(string)
 	if !ok {
-		return oauth.Tokens{}, errors.Wrap(err, "Missing id_token")
+		return oauth.Tokens{}, errors.New("Missing id_token")
 	}
 
 	tokens := oauth.Tokens{
 		RefreshToken: refreshToken,
 		AccessToken:  newToken.AccessToken,
 		Expiry:       newToken.Expiry,
+		Issued:       issued,
 		IDToken:      idToken,
+		Session:      oauth.SessionOIDC,
 	}
 
 	err = r.tokensCache.Put(tokens)
@@ -0,0 +1,75 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+func TestRefreshOAuthTokens(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    map[string]interface{}
+		expectedErr string
+	}{
+		{
+			name: "refreshed",
+			response: map[string]interface{}{
+				"access_token": "ABCDEFGHIJKLMNOP1234567890",
+				"id_token":     "0123456789ABCDEF",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			},
+		},
+		{
+			name: "missing id_token",
+			response: map[string]interface{}{
+				"access_token": "ABCDEFGHIJKLMNOP1234567890",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			},
+			expectedErr: "Missing id_token",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Nil(t, r.ParseForm())
+				assert.Equal(t, "ABCDEFGHIJKLMNOP", r.Form.Get("refresh_token"))
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(test.response)
+			}))
+			defer server.Close()
+
+			cognitoConfig := &config.Config{
+				ClientID: "ABCDEFGHIJK",
+				TokenURL: server.URL + "/oauth2/token",
+			}
+			tokenCache := oauth.NewMemoryCache(false)
+			refresher := NewTokensRefresher(cognitoConfig, tokenCache)
+
+			tokens, err := refresher.RefreshOAuthTokens("ABCDEFGHIJKLMNOP")
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "ABCDEFGHIJKLMNOP1234567890", tokens.AccessToken)
+			assert.Equal(t, "ABCDEFGHIJKLMNOP", tokens.RefreshToken)
+			assert.Equal(t, "0123456789ABCDEF", tokens.IDToken)
+			assert.Equal(t, oauth.SessionOIDC, tokens.Session)
+			assert.False(t, tokens.HasExpired(0))
+
+			cached, err := tokenCache.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, tokens, cached, "tokens were cached")
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{FormatText, FormatJSON}
+
+// LoginResult summarises a login, without any secrets.
+type LoginResult struct {
+	Profile    string    `json:"profile"`
+	IdentityID string    `json:"identity_id"`
+	Username   string    `json:"username,omitempty"`
+	Email      string    `json:"email,omitempty"`
+	Expiry     time.Time `json:"expiry"`
+}
+
+// NewLoginResult creates a login result from the tokens and credentials.
+func NewLoginResult(profile string, tokens oauth.Tokens, credentials awscreds.Credentials) LoginResult {
+	result := LoginResult{
+		Profile:    profile,
+		IdentityID: credentials.IdentityID,
+		Expiry:     credentials.Expiry,
+	}
+	// The claims are only for display, so a token we can't decode isn't an error.
+	if claims, err := oauth.ParseClaims(tokens.IDToken); err == nil {
+		result.Username = claims.Username
+		result.Email = claims.Email
+	}
+	return result
+}
+
+// Print out the login result in the given format.
+func (r LoginResult) Print(w io.Writer, format string) error {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal login result")
+		}
+		fmt.Fprintln(w, string(data))
+	case FormatText:
+		table := uitable.New()
+		table.MaxColWidth = 80
+		table.AddRow("Profile:", r.Profile)
+		table.AddRow("Identity ID:", r.IdentityID)
+		if r.Username != "" {
+			table.AddRow("Username:", r.Username)
+		}
+		if r.Email != "" {
+			table.AddRow("Email:", r.Email)
+		}
+		table.AddRow("Credentials expire:", r.Expiry.Local().Format(time.RFC1123))
+		fmt.Fprintln(w, table)
+	default:
+		return errors.Errorf("unsupported output format: %s", format)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package password
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"strings"
+)
+
+// IsBreached checks the password against the bundled breached password list.
+//
+// The list only holds SHA-1 hashes, grouped by their first five characters in
+// the same way as the k-anonymity range API, so no network calls are made. It
+// is regenerated from a plain text list with `go run gen.go <passwords file>`.
+func IsBreached(password string) bool {
+	hash := strings.ToUpper(fmt.Sprintf("%x", sha1.Sum([]byte(password))))
+	for _, suffix := range breachedHashes[hash[:5]] {
+		if suffix == hash[5:] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+// Code generated by go run gen.go; DO NOT EDIT.
+
+package password
+
+// breachedHashes maps the first five characters of the upper case SHA-1 hash of
+// a breached password to the remaining suffixes, like the k-anonymity range API.
+var breachedHashes = map[string][]string{
+	"00683": {"9D264A38B7F58E5C8130447528BF4B7AEE1"},
+	"019DB": {"0BFD5F85951CB46E4452E9642858C004155"},
+	"01B30": {"7ACBA4F54F55AAFC33BB06BBBF6CA803E9A"},
+	"02726": {"D40F378E716981C4321D60BA3A325ED6A4C"},
+	"02E0A": {"999C50B1F88DF7A8F5A04E1B76B35EA6A88"},
+	"043A5": {"58250409758B64F73D07D7F06B3DF654BC0"},
+	"05FE7": {"461C607C33229772D402505601016A7D0EA"},
+	"08B31": {"4F0E1E2C41EC92C3735910658E5A82C6BA7"},
+	"0C6D4": {"7A02431F6D346DC9CBCE7219174CF1A47D8"},
+	"0F0D9": {"59BCA569BF2B0A8BFF3E2F1E88920EE7C5F"},
+	"0F125": {"41AFCCE175FB34BB05A79C95B76E765488B"},
+	"10C28": {"F9CF0668595D45C1090A7B4A2AE98EDFA58"},
+	"112A2": {"B437CEA0196C6D442BBC3AF9385C8EC50E5"},
+	"12E92": {"93EC6B30C7FA8A0926AF42807E929C1684F"},
+	"13247": {"8A70D3EDEE9DDE642DB29E381343D76D82C"},
+	"14116": {"78A0B9E25EE2F7C8B2F7AC92B6A74B3F9C5"},
+	"17B9E": {"1C64588C7FA6419B4D29DC1F4426279BA01"},
+	"18C28": {"604DD31094A8D69DAE60F1BCD347F1AFC5A"},
+	"1999E": {"4893F732BA38B948DBE8D34ED48CD54F058"},
+	"1A0C8": {"EE36DF152800D2531C05FA2065F452B09B3"},
+	"1CB5B": {"D5A9E45420321F44C72DA5D90D7F0432FFB"},
+	"1E17F": {"D881EBAA6394AE8A8F6C7F8EF171A52ACA8"},
+	"1F3C5": {"3AE14626035383B39C207564D32D083E8FD"},
+	"1F82C": {"942BEFDA29B6ED487A51DA199F78FCE7F05"},
+	"1FC85": {"4110E5532480000542834F453DE31936C2F"},
+	"20BEE": {"D61F5D64368B9ABA66E91A1D2A090A0D4AE"},
+	"20EAB": {"E5D64B0E216796E834F52D61FD0B70332FC"},
+	"21BD1": {"2DC183F740EE76F27B78EB39C8AD972A757"},
+	"232BA": {"BB0952422462C6AE902BA4E7A7FD1B35CC7"},
+	"25846": {"5759831222D475216E3266E71E3567310DD"},
+	"25C2C": {"9AFDD83B8D34234AA2881CC341C09689AAA"},
+	"2C490": {"B8E68B92E79CE344C25F3D87FC297D12346"},
+	"2C4C3": {"891E2AC6958E9810A1E49C6705784FBFA1A"},
+	"2D27B": {"62C597EC858F6E7B54E7E58525E6A95E6D8"},
+	"31D7C": {"A20FE97CE82C52BE0C16DC9F94EC73CFAEF"},
+	"32715": {"6AB287C6AA52C8670E13163FC1BF660ADD4"},
+	"32CA9": {"FC1A0F5B6330E3F4C8C1BBECDE9BEDB9573"},
+	"34512": {"0426285FF8B1D43653A4D078170B4761F75"},
+	"38457": {"3ACB0BB050486295419F9E1AE32C1D83889"},
+	"3A960": {"464D36C1B8BAD183ED57EE79C0E39953CCE"},
+	"3ACD0": {"BE86DE7DCCCDBF91B20F94A68CEA535922D"},
+	"3D0F3": {"B9DDCACEC30C4008C5E030E6C13A478CB4F"},
+	"3D4F2": {"BF07DC1BE38B20CD6E46949A1071F9D0E3D"},
+	"3FCFC": {"1F7F34E78A937E81171BA51DC39538DB993"},
+	"40123": {"E9C6273385EA69892C48C80AA6CB25B9113"},
+	"403E3": {"5A2B0243D40400AF6BB358B5C546CDDD981"},
+	"42331": {"37D1C510F2E55BA5CB220B864B11033F156"},
+	"48058": {"E0C99BF7D689CE71C360699A14CE2F99774"},
+	"48EFC": {"4851E15940AF5D477D3C0CE99211A70A3BE"},
+	"496B1": {"05D56E7D96B2278CF0952CE90992FFF3860"},
+	"4ACEB": {"EF29D98E2B58085D7481C92130B33D5DF6B"},
+	"4BE30": {"D9814C6D4E9800E0D2EA9EC9FB00EFA887B"},
+	"4BFE0": {"29D971DDB359DABED0D0AB968A329ED0AB0"},
+	"4D0FB": {"475B242228032CBDF6D53924D2538DF037B"},
+	"4D901": {"2B4A77A9524D675DAD27C3276AB5705E5E8"},
+	"4EAAF": {"0993F35C7E5BC20CE93E6EC27065CD8E6A6"},
+	"4F26A": {"EAFDB2367620A393C973EDDBE8F8B846EBD"},
+	"53649": {"F6E45138EF119C955D04BF042562F6E2946"},
+	"56259": {"DD1C4EA0117CD601FFF7AEFA0E8892A3B25"},
+	"59033": {"478180D07080D5E4F3BAA0099996C364162"},
+	"5A46B": {"8253D07320A14CACE9B4DCBF80F93DCEF04"},
+	"5BAA6": {"1E4C9B93F3F0682250B6CF8331B7EE68FD8"},
+	"5BD53": {"91DAD2F07F81659FEA81D454D30A4A6BD2A"},
+	"5C17F": {"A03E6D5FC247565E1CD8FFA70E1BFE5B8D9"},
+	"5C6AC": {"A6504E010FC38BDBF9B940CAA1D463407CF"},
+	"5C6D9": {"EDC3A951CDA763F650235CFC41A3FC23FE8"},
+	"5CEC1": {"75B165E3D5E62C9E13CE848EF6FEAC81BFF"},
+	"5D70C": {"3D101EFD9CC0A69F4DF2DDF33B21E641F6A"},
+	"5F50A": {"84C1FA3BCFF146405017F36AEC1A10A9E38"},
+	"5F802": {"11CCB43CD491C4E2FFBBDA4C7F6BA0FF604"},
+	"5FA33": {"9BBBB1EEACED3B52E54F44576AAF0D77D96"},
+	"601F1": {"889667EFAEBB33B8C12572835DA3F027F78"},
+	"62F0E": {"DEB28DBD41F7167456FD2E7DBCCCBB8768E"},
+	"6367C": {"48DD193D56EA7B0BAAD25B19455E529F5EE"},
+	"64356": {"BCFAE350C970263C1CE575185B289F7B836"},
+	"66481": {"9D8C5343676C9225B5ED00A5CDC6F3A1FF3"},
+	"6C616": {"F7C2D2FDE9018A09F06EAEFCFC7582BC7BA"},
+	"6E112": {"6F61663FAB8BC4BF7C73BF53613143E802F"},
+	"6E2F9": {"E6111E77EDD0C446EA7A84E25323D137A61"},
+	"70352": {"F41061EDA4FF3C322094AF068BA70C3B38B"},
+	"70CCD": {"9007338D6D81DD3B6271621B9CF9A97EA00"},
+	"7110E": {"DA4D09E062AA5E4A390B0A572AC0D2C0220"},
+	"71985": {"5E8F4EBD94341277B0B0D50B75C5187133F"},
+	"7212A": {"9E01329EA93A57F574BD9BF77695D5FDCA4"},
+	"721D6": {"5122734734800A1EDD6E68C03210E7B2ACA"},
+	"7288E": {"DD0FC3FFCBE93A0CF06E3568E28521687BC"},
+	"73165": {"52D550131F753F1218B7661AC46B9582ABF"},
+	"7346A": {"84E2A9CF8C909C453E35B72866CD5237DEE"},
+	"74A87": {"1ACBF060DDA5FC7260D05A5924A34E4C0E7"},
+	"75973": {"0A97E4373F3A0EE12805DB065E3A4A649A5"},
+	"775BB": {"961B81DA1CA49217A48E533C832C337154A"},
+	"789B4": {"9606C321C8CF228D17942608EFF0CCC4171"},
+	"7AB51": {"5D12BD2CF431745511AC4EE13FED15AB578"},
+	"7C222": {"FB2927D828AF22F592134E8932480637C0D"},
+	"7C4A8": {"D09CA3762AF61E59520943DC26494F8941B"},
+	"7C6A6": {"1C68EF8B9B6B061B28C348BC1ED7921CB53"},
+	"7D8F4": {"B4B4613DC7E15333E6449692AD4AF502D1D"},
+	"7ECFD": {"8F97B4729C6FF0799B0B4D40F870083B461"},
+	"81941": {"ADD3E463581722BAC84D02282CAFB1C32C2"},
+	"862BF": {"FD3A14F343F266DE6AE527E300E23798289"},
+	"895B3": {"17C76B8E504C2FB32DBB4420178F60CE321"},
+	"89E89": {"C17F877CA2821B557F633CEC3253B0AA941"},
+	"8A5C1": {"DA8F7FB3D1EC1266DB175AFE2B8F6BC745C"},
+	"8BE3C": {"943B1609FFFBFC51AAD666D0A04ADF83C9D"},
+	"8CB22": {"37D0679CA88DB6464EAC60DA96345513964"},
+	"8D6E3": {"4F987851AA599257D3831A1AF040886842F"},
+	"92119": {"E2C63E9366ACFEFE818B50537A85577E2DB"},
+	"929D3": {"BA22D02B494DD0971784A3700C3DBF1D89F"},
+	"93EC7": {"1B22793A81569C94CA17E4D9C293D8E201F"},
+	"971A8": {"AD6B5885899CA673BD3C0E5A68296D77CDC"},
+	"98E30": {"02450246538ADCFB1E5FF3C89071BC45C29"},
+	"9AC20": {"922B054316BE23842A5BCA7D69F29F69D77"},
+	"9F2FE": {"B0F1EF425B292F2F94BC8482494DF430413"},
+	"A29C5": {"7C6894DEE6E8251510D58C07078EE3F49BF"},
+	"A2C90": {"1C8C6DEA98958C219F6F2D038C44DC5D362"},
+	"A2DFE": {"1A4892F4F1B843B57D2C512C113562D948D"},
+	"A57B5": {"B0AC821C408325CDAF9ABC6262D77FE3174"},
+	"A642A": {"77ABD7D4F51BF9226CEAF891FCBB5B299B8"},
+	"A7D57": {"9BA76398070EAE654C30FF153A4C273272A"},
+	"A94A8": {"FE5CCB19BA61C4C0873D391E987982FBBD3"},
+	"AAF4C": {"61DDCC5E8A2DABEDE0F3B482CD9AEA9434D"},
+	"AB87D": {"24BDC7452E55738DEB5F868E1F16DEA5ACE"},
+	"AF897": {"8B1797B72ACFFF9595A5A2A373EC3D9106D"},
+	"AFABA": {"63333C5D52C1088C40BC0F10821CD520468"},
+	"AFBA1": {"37331D0450D9FB52DF738268407E0A594A4"},
+	"B0399": {"D2029F64D445BD131FFAA399A42D2F8E7DC"},
+	"B1B37": {"73A05C0ED0176787A4F1574FF0075F7521E"},
+	"B2E98": {"AD6F6EB8508DD6A14CFA704BAD7F05F6FB1"},
+	"B2EE6": {"0370AD57D9BC3877E9024C507AB99303A64"},
+	"B3ACA": {"92C793EE0E9B1A9B0A5F5FC044E05140DF3"},
+	"B44DD": {"A1DADD351948FCACE1856ED97366E679239"},
+	"B5C33": {"34BD0CF859D6D2E7B2F813DBE1BCD433228"},
+	"B7A87": {"5FC1EA228B9061041B7CEC4BD3C52AB3CE3"},
+	"B7C40": {"B9C66BC88D38A59E554C639D743E77F1B65"},
+	"B80A9": {"AED8AF17118E51D4D0C2D7872AE26E2109E"},
+	"BCEF7": {"A046258082993759BADE995B3AE8BEE26C7"},
+	"BFE54": {"CAA6D483CC3887DCE9D1B8EB91408F1EA7A"},
+	"C0B13": {"7FE2D792459F26FF763CCE44574A5B5AB03"},
+	"C129B": {"324AEE662B04ECCF68BABBA85851346DFF9"},
+	"C28C9": {"70B4D9E49C3746E9F2C6F55DB43039A96C9"},
+	"C5325": {"5317BB11707D0F614696B3CE6F221D0E2F2"},
+	"C6026": {"6A8ADAD2F8EE67D793B4FD3FD0FFD73CC61"},
+	"C6922": {"B6BA9E0939583F973BC1682493351AD4FE8"},
+	"C984A": {"ED014AEC7623A54F0591DA07A85FD4B762D"},
+	"CB45C": {"671CBC500627EA424EEA5F91996221B5935"},
+	"CBFDA": {"C6008F9CAB4083784CBD1874F76618D2A97"},
+	"CC9F8": {"16A42431CF852CDC7A3FAD42A6F65FFCE24"},
+	"CDF54": {"7ED4C64E6994AF35CFCD69C4204C9227A97"},
+	"CEDF4": {"1FCCB586DC39E1CE34BB482F0AFE557B49F"},
+	"D033E": {"22AE348AEB5660FC2140AEC35850C4DA997"},
+	"D318F": {"44739DCED66793B1A603028133A76AE680E"},
+	"D4F55": {"DEC8C7BC9675182779E564FAE1327D30F9B"},
+	"D6955": {"D9721560531274CB8F50FF595A9BD39D66F"},
+	"D6CFE": {"5E76C8347BC803168FE861F69FCC69CC79C"},
+	"D869D": {"B7FE62FB07C25A0403ECAEA55031744B5FB"},
+	"D8CD1": {"0B920DCBDB5163CA0185E402357BC27C265"},
+	"DD5FE": {"F9C1C1DA1394D6D34B248C51BE2AD740840"},
+	"DDDD5": {"D7B474D2C78EBBB833789C4BFD721EDF4BF"},
+	"DE346": {"0832EA070EFFABBC7032D7594BBDE1BB120"},
+	"DF70F": {"9B975B42116EE6C0231A7E6EAD0BBB283AA"},
+	"E0C95": {"748A455C27A80FD289269120D4944D1F318"},
+	"E35BE": {"CE6C5E6E0E86CA51D0440E92282A9D6AC8A"},
+	"E38AD": {"214943DAAD1D64C102FAEC29DE4AFE9DA3D"},
+	"E3CD9": {"F6469FC3E1ACFB9F2BDBFC5A3D2BBB8E2AD"},
+	"E5E9F": {"A1BA31ECD1AE84F75CAAA474F3A663F05F4"},
+	"E6852": {"777C0260493DE41FB43918AB07BBB3A659C"},
+	"E68E1": {"1BE8B70E435C65AEF8BA9798FF7775C361E"},
+	"E6B6A": {"FBD6D76BB5D2041542D7D2E3FAC5BB05593"},
+	"E8126": {"C64C3486E84081FFFAD6A0AB22D4267BB41"},
+	"EC1E7": {"FB8656DBA32737ACABC2E5A1FB2D02A973F"},
+	"EC408": {"3CA341DA86269204F1FDEBBA909F0F5699E"},
+	"ED9D3": {"D832AF899035363A69FD53CD3BE8F71501C"},
+	"EE157": {"9CAE4261C7AE9EE79AEA9CBE88D893EB4B2"},
+	"EE8D8": {"728F435FD550F83852AABAB5234CE1DA528"},
+	"EF842": {"0D70DD7676E04BEA55F405FA39B022A90C8"},
+	"F2847": {"B1BD9624F927E979C1846D9FE17DD65F518"},
+	"F2A12": {"F187EBB7080BD75AAC9160214E6B1E49F7D"},
+	"F2B14": {"F68EB995FACB3A1C35287B778D5BD785511"},
+	"F3215": {"7A45887E4FE5ADC0B5198F7EC4920A526D7"},
+	"F3BBB": {"D66A63D4BF1747940578EC3D0103530E21D"},
+	"F4A69": {"973E7B0BF9D160F9F60E3C3ACD2494BEB0D"},
+	"F56F7": {"B238BD04695D6D8AED8FA427A8FAF413BCF"},
+	"F58CF": {"5E7E10F195E21B553096D092C763ED18B0E"},
+	"F7C3B": {"C1D808E04732ADF679965CCC34CA7AE3441"},
+	"F865B": {"53623B121FD34EE5426C792E5C33AF8C227"},
+	"FA9BE": {"B99E4029AD5A6615399E7BBAE21356086B3"},
+	"FAC67": {"3092FBDCAB2CD92EFC19675F2750ED97CA1"},
+	"FBA9F": {"1C9AE2A8AFE7815C9CDD492512622A66302"},
+	"FC84A": {"AA687374AED41957693F32664E5F4981862"},
+}
@@ -0,0 +1,88 @@
+//go:build ignore
+// +build ignore
+
+// This program generates breached_hashes.go from a list of breached
+// passwords, one per line. Only the SHA-1 hashes are kept.
+//
+// Usage: go run gen.go <passwords file>
+package main
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const tpl = `// Code generated by go run gen.go; DO NOT EDIT.
+
+package password
+
+// breachedHashes maps the first five characters of the upper case SHA-1 hash of
+// a breached password to the remaining suffixes, like the k-anonymity range API.
+var breachedHashes = map[string][]string{
+{{- range .}}
+	"{{.Prefix}}": { {{- range $i, $s := .Suffixes}}{{if $i}}, {{end}}"{{$s}}"{{end -}} },
+{{- end}}
+}
+`
+
+type entry struct {
+	Prefix   string
+	Suffixes []string
+}
+
+func main() {
+	if len(os.Args) != 2 {
+		log.Fatalln("Usage: go run gen.go <passwords file>")
+	}
+
+	file, err := os.Open(os.Args[1])
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer file.Close()
+
+	ranges := map[string]map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		password := strings.TrimSpace(scanner.Text())
+		if password == "" {
+			continue
+		}
+		hash := strings.ToUpper(fmt.Sprintf("%x", sha1.Sum([]byte(password))))
+		if ranges[hash[:5]] == nil {
+			ranges[hash[:5]] = map[string]bool{}
+		}
+		ranges[hash[:5]][hash[5:]] = true
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalln(err)
+	}
+
+	var entries []entry
+	for prefix, suffixes := range ranges {
+		e := entry{Prefix: prefix}
+		for suffix := range suffixes {
+			e.Suffixes = append(e.Suffixes, suffix)
+		}
+		sort.Strings(e.Suffixes)
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Prefix < entries[j].Prefix })
+
+	out, err := os.Create("breached_hashes.go")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	defer out.Close()
+
+	err = template.Must(template.New("breached").Parse(tpl)).Execute(out, entries)
+	if err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -0,0 +1,57 @@
+package password
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/skpr/cognito-auth/pkg/config"
+)
+
+// symbols are the special characters accepted by Cognito.
+const symbols = "^$*.[]{}()?\"!@#%&/\\,><':;|_~`=+- "
+
+// PolicyError lists the password policy rules a password does not meet.
+type PolicyError struct {
+	Rules []string
+}
+
+// Error implements the error interface.
+func (e *PolicyError) Error() string {
+	return "Password does not meet the password policy:\n  - " + strings.Join(e.Rules, "\n  - ")
+}
+
+// Validate checks a password against the password policy and the breached
+// password list. The policy may be nil, in which case only the breached
+// password list is checked.
+func Validate(policy *config.PasswordPolicy, password string) error {
+	var rules []string
+
+	if policy != nil {
+		if len([]rune(password)) < policy.MinimumLength {
+			rules = append(rules, fmt.Sprintf("must be at least %d characters", policy.MinimumLength))
+		}
+		if policy.RequireLowercase && strings.IndexFunc(password, unicode.IsLower) < 0 {
+			rules = append(rules, "must contain a lowercase letter")
+		}
+		if policy.RequireUppercase && strings.IndexFunc(password, unicode.IsUpper) < 0 {
+			rules = append(rules, "must contain an uppercase letter")
+		}
+		if policy.RequireNumbers && strings.IndexFunc(password, unicode.IsDigit) < 0 {
+			rules = append(rules, "must contain a number")
+		}
+		if policy.RequireSymbols && !strings.ContainsAny(password, symbols) {
+			rules = append(rules, "must contain a special character")
+		}
+	}
+
+	if IsBreached(password) {
+		rules = append(rules, "must not be a known breached password")
+	}
+
+	if len(rules) > 0 {
+		return &PolicyError{Rules: rules}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package password
+
+import (
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	policy := &config.PasswordPolicy{
+		MinimumLength:    12,
+		RequireLowercase: true,
+		RequireUppercase: true,
+		RequireNumbers:   true,
+		RequireSymbols:   true,
+	}
+
+	err := Validate(policy, "abc")
+	assert.Equal(t, []string{
+		"must be at least 12 characters",
+		"must contain an uppercase letter",
+		"must contain a number",
+		"must contain a special character",
+	}, err.(*PolicyError).Rules)
+
+	err = Validate(policy, "P@ssw0rd123")
+	assert.Equal(t, []string{
+		"must be at least 12 characters",
+		"must not be a known breached password",
+	}, err.(*PolicyError).Rules)
+
+	assert.Nil(t, Validate(policy, "Correct-Horse-Battery-9"))
+	assert.Nil(t, Validate(nil, "correcthorsebatterystaple"))
+	assert.NotNil(t, Validate(nil, "password123"))
+}
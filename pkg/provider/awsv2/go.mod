@@ -0,0 +1,29 @@
+module github.com/skpr/cognito-auth/pkg/provider/awsv2
+
+go 1.24
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.47.1
+	github.com/skpr/cognito-auth v0.0.0-20261019120143-b1c6a18aa28b
+	github.com/stretchr/testify v1.4.0
+)
+
+require (
+	github.com/aws/aws-sdk-go v1.23.22 // indirect
+	github.com/aws/smithy-go v1.28.1 // indirect
+	github.com/danieljoos/wincred v1.0.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/godbus/dbus v4.1.0+incompatible // indirect
+	github.com/jmespath/go-jmespath v0.0.0-20180206201540-c2b33e8439af // indirect
+	github.com/pkg/errors v0.8.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/zalando/go-keyring v0.0.0-20190913082157-62750a1ff80d // indirect
+	golang.org/x/crypto v0.0.0-20190911031432-227b76d455e7 // indirect
+	golang.org/x/net v0.0.0-20190916140828-c8589233b77d // indirect
+	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
+)
+
+// Builds against the root module in this repository. Modules which import
+// this one ignore the replace, and use the version required above.
+replace github.com/skpr/cognito-auth => ../../..
@@ -0,0 +1,53 @@
+// Package awsv2 provides credentials to aws-sdk-go-v2.
+//
+// It is a separate module so that aws-sdk-go-v2, and the Go version it
+// requires, is only needed by applications which use it.
+package awsv2
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/skpr/cognito-auth/pkg/provider"
+)
+
+// Provider provides AWS credentials to aws-sdk-go-v2 from the credentials
+// resolver. It implements aws.CredentialsProvider, and should be wrapped in an
+// aws.CredentialsCache so credentials are only refreshed when they expire.
+type Provider struct {
+	provider *provider.Provider
+}
+
+// New creates a new provider.
+//
+// The credentials expire once they are within the expiry margin, which should
+// match the margin of the credentials resolver so it refreshes them when asked.
+func New(credentialsGetter provider.CredentialsGetter, expiryMargin time.Duration) *Provider {
+	return &Provider{
+		provider: provider.New(credentialsGetter, expiryMargin),
+	}
+}
+
+// NewCredentialsCache creates an aws.CredentialsCache using a new provider.
+func NewCredentialsCache(credentialsGetter provider.CredentialsGetter, expiryMargin time.Duration) *aws.CredentialsCache {
+	return aws.NewCredentialsCache(New(credentialsGetter, expiryMargin))
+}
+
+// Retrieve gets the credentials for aws-sdk-go-v2.
+func (p *Provider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	creds, err := p.provider.RetrieveCredentials()
+	if err != nil {
+		return aws.Credentials{Source: provider.ProviderName}, err
+	}
+
+	return aws.Credentials{
+		AccessKeyID:     creds.AccessKey,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		Source:          provider.ProviderName,
+		CanExpire:       true,
+		Expires:         p.provider.ExpiresAt(),
+	}, nil
+}
@@ -0,0 +1,44 @@
+package awsv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+)
+
+type credentialsGetter struct {
+	expiry time.Time
+	calls  int
+}
+
+func (g *credentialsGetter) GetAwsCredentials() (awscreds.Credentials, error) {
+	g.calls++
+	return awscreds.Credentials{
+		AccessKey:       "ABCDEFGHIJKLMNOP",
+		SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
+		SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU",
+		Expiry:          g.expiry,
+	}, nil
+}
+
+func TestProvider(t *testing.T) {
+	getter := &credentialsGetter{expiry: time.Now().Add(time.Hour)}
+	cache := NewCredentialsCache(getter, 5*time.Minute)
+
+	creds, err := cache.Retrieve(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "ABCDEFGHIJKLMNOP", creds.AccessKeyID)
+	assert.Equal(t, "ABCDEFGHIJKLMNOP1234567890", creds.SecretAccessKey)
+	assert.Equal(t, "1234567890ABCDEFGHIJKLMNOPQRSTU", creds.SessionToken)
+	assert.True(t, creds.CanExpire)
+	assert.Equal(t, getter.expiry.Add(-5*time.Minute), creds.Expires)
+
+	// Cached until they expire.
+	_, err = cache.Retrieve(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, getter.calls)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/pkg/errors"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+)
+
+// ProviderName is the name of the provider, reported with the credentials.
+const ProviderName = "CognitoAuthProvider"
+
+// CredentialsGetter gets the AWS credentials, refreshing them if needed.
+type CredentialsGetter interface {
+	GetAwsCredentials() (awscreds.Credentials, error)
+}
+
+// Provider provides AWS credentials to the AWS SDK from the credentials
+// resolver, so embedding applications get credentials which refresh
+// automatically. It implements credentials.Provider and credentials.Expirer
+// for aws-sdk-go.
+type Provider struct {
+	mutex             sync.RWMutex
+	credentialsGetter CredentialsGetter
+	expiryMargin      time.Duration
+	expiry            time.Time
+}
+
+// New creates a new provider.
+//
+// The credentials are treated as expired once they are within the expiry
+// margin, which should match the margin of the credentials resolver so it
+// refreshes them when asked.
+func New(credentialsGetter CredentialsGetter, expiryMargin time.Duration) *Provider {
+	return &Provider{
+		credentialsGetter: credentialsGetter,
+		expiryMargin:      expiryMargin,
+	}
+}
+
+// NewCredentials creates aws-sdk-go credentials using a new provider.
+func NewCredentials(credentialsGetter CredentialsGetter, expiryMargin time.Duration) *credentials.Credentials {
+	return credentials.NewCredentials(New(credentialsGetter, expiryMargin))
+}
+
+// RetrieveCredentials gets the credentials, recording when they expire.
+func (p *Provider) RetrieveCredentials() (awscreds.Credentials, error) {
+	creds, err := p.credentialsGetter.GetAwsCredentials()
+	if err != nil {
+		return awscreds.Credentials{}, errors.Wrap(err, "Failed to get credentials")
+	}
+
+	p.mutex.Lock()
+	p.expiry = creds.RefreshAt(p.expiryMargin)
+	p.mutex.Unlock()
+
+	return creds, nil
+}
+
+// Retrieve gets the credentials for aws-sdk-go.
+func (p *Provider) Retrieve() (credentials.Value, error) {
+	creds, err := p.RetrieveCredentials()
+	if err != nil {
+		return credentials.Value{ProviderName: ProviderName}, err
+	}
+
+	return credentials.Value{
+		AccessKeyID:     creds.AccessKey,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		ProviderName:    ProviderName,
+	}, nil
+}
+
+// IsExpired checks if the credentials are expired, or will expire within the margin.
+func (p *Provider) IsExpired() bool {
+	return !time.Now().Before(p.ExpiresAt())
+}
+
+// ExpiresAt returns when the credentials should be refreshed, which is the
+// expiry less the margin. It is the zero time before credentials are retrieved.
+func (p *Provider) ExpiresAt() time.Time {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.expiry
+}
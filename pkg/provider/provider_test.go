@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+)
+
+type credentialsGetter struct {
+	expiry time.Time
+	err    error
+	calls  int
+}
+
+func (g *credentialsGetter) GetAwsCredentials() (awscreds.Credentials, error) {
+	g.calls++
+	return awscreds.Credentials{
+		AccessKey:       "ABCDEFGHIJKLMNOP",
+		SecretAccessKey: "ABCDEFGHIJKLMNOP1234567890",
+		SessionToken:    "1234567890ABCDEFGHIJKLMNOPQRSTU",
+		Expiry:          g.expiry,
+	}, g.err
+}
+
+func TestProvider(t *testing.T) {
+	expiry := time.Now().Add(time.Hour)
+	getter := &credentialsGetter{expiry: expiry}
+	provider := New(getter, 5*time.Minute)
+
+	assert.True(t, provider.IsExpired(), "expired before retrieving")
+
+	value, err := provider.Retrieve()
+	assert.Nil(t, err)
+	assert.Equal(t, "ABCDEFGHIJKLMNOP", value.AccessKeyID)
+	assert.Equal(t, "ABCDEFGHIJKLMNOP1234567890", value.SecretAccessKey)
+	assert.Equal(t, "1234567890ABCDEFGHIJKLMNOPQRSTU", value.SessionToken)
+	assert.Equal(t, ProviderName, value.ProviderName)
+
+	assert.False(t, provider.IsExpired())
+	assert.Equal(t, expiry.Add(-5*time.Minute), provider.ExpiresAt())
+
+	getter.expiry = time.Now().Add(time.Minute)
+	_, err = provider.Retrieve()
+	assert.Nil(t, err)
+	assert.True(t, provider.IsExpired(), "expired within the margin")
+
+	getter.err = errors.New("login required")
+	_, err = provider.Retrieve()
+	assert.EqualError(t, err, "Failed to get credentials: login required")
+}
+
+func TestNewCredentials(t *testing.T) {
+	getter := &credentialsGetter{expiry: time.Now().Add(time.Hour)}
+	creds := NewCredentials(getter, 5*time.Minute)
+
+	value, err := creds.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "ABCDEFGHIJKLMNOP", value.AccessKeyID)
+
+	// Cached until they expire.
+	_, err = creds.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, getter.calls)
+
+	expiresAt, err := creds.ExpiresAt()
+	assert.Nil(t, err)
+	assert.Equal(t, getter.expiry.Add(-5*time.Minute), expiresAt)
+}
@@ -0,0 +1,148 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/securefile"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// PassphraseEnv is the environment variable holding the encrypted file passphrase.
+	PassphraseEnv = "COGNITO_AUTH_PASSPHRASE"
+
+	saltSize = 16
+	keySize  = 32
+
+	// scrypt parameters recommended for interactive logins.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// EncryptedFile stores a secret in a file, encrypted with AES-GCM.
+//
+// The key is derived from the passphrase with scrypt, using a random salt
+// stored at the start of the file.
+type EncryptedFile struct {
+	filename   string
+	passphrase []byte
+}
+
+// NewEncryptedFile creates a new encrypted file.
+func NewEncryptedFile(filename string, passphrase []byte) *EncryptedFile {
+	return &EncryptedFile{
+		filename:   filename,
+		passphrase: passphrase,
+	}
+}
+
+// LoadPassphrase loads the passphrase from the key file, or from the
+// environment if no key file is configured.
+func LoadPassphrase(keyFile string) ([]byte, error) {
+	if keyFile != "" {
+		data, err := securefile.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to read key file")
+		}
+		passphrase := bytes.TrimSpace(data)
+		if len(passphrase) == 0 {
+			return nil, errors.New("key file is empty")
+		}
+		return passphrase, nil
+	}
+
+	passphrase := os.Getenv(PassphraseEnv)
+	if passphrase == "" {
+		return nil, errors.Errorf("not found: creds_key_file or %s", PassphraseEnv)
+	}
+	return []byte(passphrase), nil
+}
+
+// Put saves a secret.
+func (f *EncryptedFile) Put(secret string) error {
+	salt := make([]byte, saltSize)
+	_, err := io.ReadFull(rand.Reader, salt)
+	if err != nil {
+		return errors.Wrap(err, "Failed to generate salt")
+	}
+
+	gcm, err := f.cipher(salt)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return errors.Wrap(err, "Failed to generate nonce")
+	}
+
+	data := append(salt, nonce...)
+	data = gcm.Seal(data, nonce, []byte(secret), nil)
+
+	err = securefile.WriteFile(f.filename, data)
+	if err != nil {
+		return errors.Wrap(err, "Failed to write encrypted file")
+	}
+	return nil
+}
+
+// Get retrieves a secret.
+func (f *EncryptedFile) Get() (string, error) {
+	data, err := securefile.ReadFile(f.filename)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to read encrypted file")
+	}
+
+	if len(data) < saltSize {
+		return "", errors.New("encrypted file is too short")
+	}
+	salt, data := data[:saltSize], data[saltSize:]
+
+	gcm, err := f.cipher(salt)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("encrypted file is too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to decrypt, check the passphrase")
+	}
+	return string(secret), nil
+}
+
+// Delete deletes a secret.
+func (f *EncryptedFile) Delete() error {
+	return os.Remove(f.filename)
+}
+
+// cipher derives the key from the passphrase and salt.
+func (f *EncryptedFile) cipher(salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(f.passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to derive key")
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create cipher")
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create GCM")
+	}
+	return gcm, nil
+}
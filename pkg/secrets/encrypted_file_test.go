@@ -0,0 +1,60 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptedFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "secret.enc")
+	file := NewEncryptedFile(filename, []byte("correct horse battery staple"))
+
+	assert.Nil(t, file.Put("access_token: ABCDEFGHIJKLMNOP"))
+
+	data, err := ioutil.ReadFile(filename)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "ABCDEFGHIJKLMNOP")
+
+	secret, err := file.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: ABCDEFGHIJKLMNOP", secret)
+
+	_, err = NewEncryptedFile(filename, []byte("wrong")).Get()
+	assert.Error(t, err)
+
+	assert.Nil(t, file.Delete())
+	_, err = file.Get()
+	assert.Error(t, err)
+}
+
+func TestLoadPassphrase(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "key")
+	assert.Nil(t, ioutil.WriteFile(keyFile, []byte("from-file\n"), 0600))
+
+	passphrase, err := LoadPassphrase(keyFile)
+	assert.Nil(t, err)
+	assert.Equal(t, "from-file", string(passphrase))
+
+	os.Setenv(PassphraseEnv, "from-env")
+	defer os.Unsetenv(PassphraseEnv)
+
+	passphrase, err = LoadPassphrase("")
+	assert.Nil(t, err)
+	assert.Equal(t, "from-env", string(passphrase))
+
+	os.Unsetenv(PassphraseEnv)
+	_, err = LoadPassphrase("")
+	assert.Error(t, err)
+}
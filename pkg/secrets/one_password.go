@@ -0,0 +1,190 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	onePasswordField = "notesPlain"
+)
+
+// OnePassword stores a secret in a 1Password secure note, using the op CLI.
+type OnePassword struct {
+	command string
+	vault   string
+	title   string
+}
+
+// onePasswordItemField is a field of a 1Password item.
+type onePasswordItemField struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Purpose string `json:"purpose"`
+	Label   string `json:"label"`
+	Value   string `json:"value"`
+}
+
+// onePasswordItem is the template used to create a 1Password item.
+type onePasswordItem struct {
+	Title    string                 `json:"title"`
+	Category string                 `json:"category"`
+	Fields   []onePasswordItemField `json:"fields"`
+}
+
+// NewOnePassword creates a new 1Password store for the titled item.
+//
+// If the vault is empty, the default vault is used.
+func NewOnePassword(vault string, title string) *OnePassword {
+	return &OnePassword{
+		command: "op",
+		vault:   vault,
+		title:   title,
+	}
+}
+
+// onePasswordListItem is an item listed by op.
+type onePasswordListItem struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Put saves a secret, replacing the existing items.
+//
+// The existing items are only deleted once the new item is created, so the
+// secret isn't lost if creating it fails. Items which fail to delete are left
+// for the next Put or Delete to clean up, as Get uses the newest item.
+func (o *OnePassword) Put(secret string) error {
+	existingIDs, err := o.itemIDs()
+	if err != nil {
+		return err
+	}
+
+	// The secret is piped in as an item template, as op can only edit fields
+	// with command line arguments, which other users can see.
+	item := onePasswordItem{
+		Title:    o.title,
+		Category: "SECURE_NOTE",
+		Fields: []onePasswordItemField{
+			{
+				ID:      onePasswordField,
+				Type:    "STRING",
+				Purpose: "NOTES",
+				Label:   onePasswordField,
+				Value:   secret,
+			},
+		},
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal item")
+	}
+
+	_, err = o.run(string(data), "item", "create", "-")
+	if err != nil {
+		return err
+	}
+
+	for _, id := range existingIDs {
+		_, _ = o.run("", "item", "delete", id)
+	}
+
+	return nil
+}
+
+// itemIDs gets the IDs of the items with the title, newest first.
+//
+// There is usually a single item, but there can be more if deleting the
+// previous item failed.
+func (o *OnePassword) itemIDs() ([]string, error) {
+	data, err := o.run("", "item", "list", "--categories", "Secure Note", "--format", "json")
+	if err != nil {
+		return nil, err
+	}
+
+	var items []onePasswordListItem
+	err = json.Unmarshal([]byte(data), &items)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshal items")
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].CreatedAt.After(items[j].CreatedAt)
+	})
+
+	var ids []string
+	for _, item := range items {
+		if item.Title == o.title {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids, nil
+}
+
+// Get retrieves a secret from the newest item.
+func (o *OnePassword) Get() (string, error) {
+	ids, err := o.itemIDs()
+	if err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", errors.Errorf("not found: %s", o.title)
+	}
+
+	data, err := o.run("", "item", "get", ids[0], "--fields", "label="+onePasswordField, "--format", "json")
+	if err != nil {
+		return "", err
+	}
+
+	var field onePasswordItemField
+	err = json.Unmarshal([]byte(data), &field)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to unmarshal item")
+	}
+
+	return field.Value, nil
+}
+
+// Delete deletes a secret, including any items left behind by a failed Put.
+func (o *OnePassword) Delete() error {
+	ids, err := o.itemIDs()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		_, err = o.run("", "item", "delete", id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run runs op in the vault.
+func (o *OnePassword) run(input string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	if o.vault != "" {
+		args = append(args, "--vault", o.vault)
+	}
+
+	cmd := exec.Command(o.command, args...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", errors.Wrapf(err, "op %s %s failed: %s", args[0], args[1], strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,113 @@
+//go:build !windows
+// +build !windows
+
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeOnePassword stores items as files in the directory, named by their ID.
+// Creating items fails while FAIL_CREATE is set, and deleting them while
+// FAIL_DELETE is set.
+const fakeOnePassword = `#!/bin/sh
+cd "$STORE" || exit 1
+case "$1 $2" in
+  "item create")
+    [ -z "$FAIL_CREATE" ] || { echo "session expired" >&2; exit 1; }
+    seq=$(( $(cat seq 2>/dev/null || echo 0) + 1 ))
+    echo "$seq" > seq
+    id="item$seq"
+    cat > "$id.json"
+    sed 's/.*"title":"\([^"]*\)".*/\1/' "$id.json" > "$id.title"
+    printf '{"id":"%s"}\n' "$id"
+    ;;
+  "item list")
+    sep=""
+    printf '['
+    for title in *.title; do
+      [ -f "$title" ] || continue
+      id=$(basename "$title" .title)
+      printf '%s{"id":"%s","title":"%s","created_at":"2020-01-01T00:00:%02dZ"}' "$sep" "$id" "$(cat "$title")" "${id#item}"
+      sep=","
+    done
+    printf ']\n'
+    ;;
+  "item get")
+    [ -f "$3.json" ] || { echo "item not found: $3" >&2; exit 1; }
+    sed 's/.*"fields":\[\(.*\)\]}$/\1/' "$3.json"
+    ;;
+  "item delete")
+    [ -z "$FAIL_DELETE" ] || { echo "network error" >&2; exit 1; }
+    [ -f "$3.json" ] || { echo "item not found: $3" >&2; exit 1; }
+    rm "$3.json" "$3.title"
+    ;;
+esac
+`
+
+func TestOnePassword(t *testing.T) {
+	dir, err := ioutil.TempDir("", "op")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	command := filepath.Join(dir, "op")
+	assert.Nil(t, ioutil.WriteFile(command, []byte(fakeOnePassword), 0700))
+	os.Setenv("STORE", dir)
+	defer os.Unsetenv("STORE")
+
+	onePassword := NewOnePassword("Private", "Cognito OAuth Tokens")
+	onePassword.command = command
+
+	assert.Nil(t, onePassword.Put("access_token: ABCDEFGHIJKLMNOP\n"))
+	secret, err := onePassword.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: ABCDEFGHIJKLMNOP\n", secret)
+
+	assert.Nil(t, onePassword.Put("access_token: QRSTUVWXYZ\n"))
+	secret, err = onePassword.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: QRSTUVWXYZ\n", secret, "existing item was replaced")
+
+	os.Setenv("FAIL_CREATE", "1")
+	err = onePassword.Put("access_token: 1234567890\n")
+	os.Unsetenv("FAIL_CREATE")
+	assert.EqualError(t, err, "op item create failed: session expired: exit status 1")
+	secret, err = onePassword.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: QRSTUVWXYZ\n", secret, "existing item was kept when creating failed")
+
+	os.Setenv("FAIL_DELETE", "1")
+	assert.Nil(t, onePassword.Put("access_token: 1234567890\n"), "the secret was saved, though the previous item wasn't deleted")
+	os.Unsetenv("FAIL_DELETE")
+	secret, err = onePassword.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: 1234567890\n", secret, "newest item was used")
+
+	assert.Nil(t, onePassword.Put("access_token: ABCDEFGHIJKLMNOP\n"))
+	ids, err := onePassword.itemIDs()
+	assert.Nil(t, err)
+	assert.Len(t, ids, 1, "previous items were cleaned up")
+	secret, err = onePassword.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: ABCDEFGHIJKLMNOP\n", secret)
+
+	other := NewOnePassword("Private", "Cognito AWS Credentials")
+	other.command = command
+	assert.Nil(t, other.Put("access_key: ABCDEFGHIJKLMNOP\n"))
+
+	os.Setenv("FAIL_DELETE", "1")
+	assert.Nil(t, onePassword.Put("access_token: QRSTUVWXYZ\n"))
+	os.Unsetenv("FAIL_DELETE")
+	assert.Nil(t, onePassword.Delete())
+	_, err = onePassword.Get()
+	assert.EqualError(t, err, "not found: Cognito OAuth Tokens")
+
+	secret, err = other.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_key: ABCDEFGHIJKLMNOP\n", secret, "items with other titles were kept")
+}
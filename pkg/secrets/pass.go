@@ -0,0 +1,57 @@
+package secrets
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Pass stores a secret with pass, the standard unix password manager.
+type Pass struct {
+	command string
+	name    string
+}
+
+// NewPass creates a new pass store for the named secret.
+func NewPass(name string) *Pass {
+	return &Pass{
+		command: "pass",
+		name:    name,
+	}
+}
+
+// Put saves a secret.
+func (p *Pass) Put(secret string) error {
+	_, err := p.run(secret, "insert", "--multiline", "--force", p.name)
+	return err
+}
+
+// Get retrieves a secret.
+func (p *Pass) Get() (string, error) {
+	return p.run("", "show", p.name)
+}
+
+// Delete deletes a secret.
+func (p *Pass) Delete() error {
+	_, err := p.run("", "rm", "--force", p.name)
+	return err
+}
+
+// run runs pass, passing the input on stdin so secrets don't appear in the process list.
+func (p *Pass) run(input string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(p.command, args...)
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", errors.Wrapf(err, "pass %s failed: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,46 @@
+//go:build !windows
+// +build !windows
+
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePass stores secrets as files in the directory, like pass does.
+const fakePass = `#!/bin/sh
+case "$1" in
+  insert) cat > "$STORE/$(basename "$4")" ;;
+  show) cat "$STORE/$(basename "$2")" ;;
+  rm) rm "$STORE/$(basename "$3")" ;;
+esac
+`
+
+func TestPass(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pass")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	command := filepath.Join(dir, "pass")
+	assert.Nil(t, ioutil.WriteFile(command, []byte(fakePass), 0700))
+	os.Setenv("STORE", dir)
+	defer os.Unsetenv("STORE")
+
+	pass := NewPass("cognito-auth/oauth-tokens")
+	pass.command = command
+
+	assert.Nil(t, pass.Put("access_token: ABCDEFGHIJKLMNOP\n"))
+
+	secret, err := pass.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: ABCDEFGHIJKLMNOP\n", secret)
+
+	assert.Nil(t, pass.Delete())
+	_, err = pass.Get()
+	assert.Error(t, err)
+}
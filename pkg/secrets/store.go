@@ -0,0 +1,70 @@
+package secrets
+
+import (
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/config"
+)
+
+// Creds stores.
+const (
+	StoreFile          = "file"
+	StoreNative        = "native"
+	StoreEncryptedFile = "encrypted-file"
+	StorePass          = "pass"
+	StoreOnePassword   = "1password"
+	StoreVault         = "vault"
+)
+
+const (
+	encryptedTokensFilename      = "oauth_tokens.enc"
+	encryptedCredentialsFilename = "aws_credentials.enc"
+)
+
+// SecretStore defines the interface for stores holding a single secret.
+type SecretStore interface {
+	Get() (string, error)
+	Put(secret string) error
+	Delete() error
+}
+
+// NewStores creates the stores for the oauth tokens and aws credentials,
+// using the creds_store from the config.
+//
+// The username is used as the keychain account, and the cache directory holds
+// the encrypted files.
+func NewStores(cognitoConfig *config.Config, cacheDir string, username string) (SecretStore, SecretStore, error) {
+	switch cognitoConfig.CredsStore {
+	case StoreEncryptedFile:
+		passphrase, err := LoadPassphrase(cognitoConfig.CredsKeyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewEncryptedFile(cacheDir+"/"+encryptedTokensFilename, passphrase), NewEncryptedFile(cacheDir+"/"+encryptedCredentialsFilename, passphrase), nil
+	case StoreNative, StorePass, StoreOnePassword, StoreVault:
+		// These stores name their items with the creds keys.
+	default:
+		return nil, nil, errors.Errorf("unsupported creds_store: %s", cognitoConfig.CredsStore)
+	}
+
+	if cognitoConfig.CredsOAuthKey == "" {
+		return nil, nil, errors.New("not found: creds_oauth_key")
+	}
+	if cognitoConfig.CredsAwsKey == "" {
+		return nil, nil, errors.New("not found: creds_aws_key")
+	}
+
+	switch cognitoConfig.CredsStore {
+	case StorePass:
+		return NewPass(cognitoConfig.CredsOAuthKey), NewPass(cognitoConfig.CredsAwsKey), nil
+	case StoreOnePassword:
+		return NewOnePassword(cognitoConfig.CredsOnePasswordVault, cognitoConfig.CredsOAuthKey), NewOnePassword(cognitoConfig.CredsOnePasswordVault, cognitoConfig.CredsAwsKey), nil
+	case StoreVault:
+		address, token, err := vaultEnv()
+		if err != nil {
+			return nil, nil, err
+		}
+		return NewVault(address, token, cognitoConfig.CredsVaultMount, cognitoConfig.CredsOAuthKey), NewVault(address, token, cognitoConfig.CredsVaultMount, cognitoConfig.CredsAwsKey), nil
+	}
+
+	return NewKeychain(cognitoConfig.CredsOAuthKey, username), NewKeychain(cognitoConfig.CredsAwsKey, username), nil
+}
@@ -0,0 +1,62 @@
+package secrets
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/config"
+)
+
+func TestNewStores(t *testing.T) {
+	cognitoConfig := &config.Config{
+		CredsOAuthKey: "cognito-auth/oauth-tokens",
+		CredsAwsKey:   "cognito-auth/aws-credentials",
+	}
+
+	cognitoConfig.CredsStore = StoreNative
+	tokens, credentials, err := NewStores(cognitoConfig, "/tmp", "me")
+	assert.Nil(t, err)
+	assert.IsType(t, &Keychain{}, tokens)
+	assert.IsType(t, &Keychain{}, credentials)
+
+	cognitoConfig.CredsStore = StorePass
+	tokens, _, err = NewStores(cognitoConfig, "/tmp", "me")
+	assert.Nil(t, err)
+	assert.Equal(t, "cognito-auth/oauth-tokens", tokens.(*Pass).name)
+
+	cognitoConfig.CredsStore = StoreOnePassword
+	cognitoConfig.CredsOnePasswordVault = "Private"
+	tokens, _, err = NewStores(cognitoConfig, "/tmp", "me")
+	assert.Nil(t, err)
+	assert.Equal(t, "Private", tokens.(*OnePassword).vault)
+
+	os.Setenv("VAULT_ADDR", "https://vault.example.com")
+	os.Setenv("VAULT_TOKEN", "s.ABCDEFGHIJKLMNOP")
+	defer os.Unsetenv("VAULT_ADDR")
+	defer os.Unsetenv("VAULT_TOKEN")
+
+	cognitoConfig.CredsStore = StoreVault
+	_, credentials, err = NewStores(cognitoConfig, "/tmp", "me")
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", credentials.(*Vault).mount)
+	assert.Equal(t, "cognito-auth/aws-credentials", credentials.(*Vault).path)
+
+	os.Setenv(PassphraseEnv, "correct horse battery staple")
+	defer os.Unsetenv(PassphraseEnv)
+
+	cognitoConfig.CredsStore = StoreEncryptedFile
+	tokens, _, err = NewStores(cognitoConfig, "/tmp", "me")
+	assert.Nil(t, err)
+	assert.Equal(t, "/tmp/oauth_tokens.enc", tokens.(*EncryptedFile).filename)
+
+	cognitoConfig.CredsStore = "keychain"
+	_, _, err = NewStores(cognitoConfig, "/tmp", "me")
+	assert.EqualError(t, err, "unsupported creds_store: keychain")
+
+	cognitoConfig.CredsStore = StorePass
+	cognitoConfig.CredsAwsKey = ""
+	_, _, err = NewStores(cognitoConfig, "/tmp", "me")
+	assert.EqualError(t, err, "not found: creds_aws_key")
+}
@@ -0,0 +1,151 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultVaultMount = "secret"
+	vaultTimeout      = 10 * time.Second
+)
+
+// Vault stores a secret in a HashiCorp Vault KV version 2 secrets engine.
+type Vault struct {
+	address string
+	token   string
+	mount   string
+	path    string
+	client  *http.Client
+}
+
+// vaultSecret is the body of KV version 2 reads and writes.
+type vaultSecret struct {
+	Data struct {
+		Value string `json:"value"`
+	} `json:"data"`
+}
+
+// vaultResponse is the response to a KV version 2 read.
+type vaultResponse struct {
+	Data vaultSecret `json:"data"`
+}
+
+// NewVault creates a new Vault store for the secret at the path.
+//
+// If the mount is empty, the default "secret" mount is used.
+func NewVault(address string, token string, mount string, path string) *Vault {
+	if mount == "" {
+		mount = defaultVaultMount
+	}
+	return &Vault{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		mount:   strings.Trim(mount, "/"),
+		path:    strings.Trim(path, "/"),
+		client: &http.Client{
+			Timeout: vaultTimeout,
+		},
+	}
+}
+
+// vaultEnv returns the Vault address and token, the same way as the vault CLI.
+func vaultEnv() (string, string, error) {
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		return "", "", errors.New("not found: VAULT_ADDR")
+	}
+
+	token := os.Getenv("VAULT_TOKEN")
+	if token != "" {
+		return address, token, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", errors.Wrap(err, "not found: VAULT_TOKEN")
+	}
+	data, err := ioutil.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		return "", "", errors.Wrap(err, "not found: VAULT_TOKEN")
+	}
+	return address, strings.TrimSpace(string(data)), nil
+}
+
+// Put saves a secret.
+func (v *Vault) Put(secret string) error {
+	var body vaultSecret
+	body.Data.Value = secret
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal secret")
+	}
+
+	_, err = v.request(http.MethodPost, "data", data)
+	return err
+}
+
+// Get retrieves a secret.
+func (v *Vault) Get() (string, error) {
+	data, err := v.request(http.MethodGet, "data", nil)
+	if err != nil {
+		return "", err
+	}
+
+	var resp vaultResponse
+	err = json.Unmarshal(data, &resp)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to unmarshal secret")
+	}
+
+	return resp.Data.Data.Value, nil
+}
+
+// Delete deletes all versions of a secret.
+func (v *Vault) Delete() error {
+	_, err := v.request(http.MethodDelete, "metadata", nil)
+	return err
+}
+
+// request makes a request to the KV API.
+func (v *Vault) request(method string, api string, body []byte) ([]byte, error) {
+	url := v.address + "/v1/" + v.mount + "/" + api + "/" + v.path
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create Vault request")
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Vault request failed")
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to read Vault response")
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.Errorf("not found: %s", v.path)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Errorf("Vault returned %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	return data, nil
+}
@@ -0,0 +1,60 @@
+package secrets
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVault(t *testing.T) {
+	secrets := map[string]string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.ABCDEFGHIJKLMNOP" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/kv/data/cognito-auth/tokens":
+			data, _ := ioutil.ReadAll(r.Body)
+			var body vaultSecret
+			_ = json.Unmarshal(data, &body)
+			secrets["cognito-auth/tokens"] = body.Data.Value
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/kv/data/cognito-auth/tokens":
+			value, ok := secrets["cognito-auth/tokens"]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var resp vaultResponse
+			resp.Data.Data.Value = value
+			_ = json.NewEncoder(w).Encode(resp)
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/kv/metadata/cognito-auth/tokens":
+			delete(secrets, "cognito-auth/tokens")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	vault := NewVault(server.URL, "s.ABCDEFGHIJKLMNOP", "kv", "cognito-auth/tokens")
+
+	assert.Nil(t, vault.Put("access_token: ABCDEFGHIJKLMNOP"))
+
+	secret, err := vault.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "access_token: ABCDEFGHIJKLMNOP", secret)
+
+	assert.Nil(t, vault.Delete())
+	_, err = vault.Get()
+	assert.EqualError(t, err, "not found: cognito-auth/tokens")
+
+	_, err = NewVault(server.URL, "wrong", "kv", "cognito-auth/tokens").Get()
+	assert.Error(t, err)
+}
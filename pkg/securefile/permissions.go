@@ -0,0 +1,17 @@
+//go:build !windows
+// +build !windows
+
+package securefile
+
+import (
+	"fmt"
+	"os"
+)
+
+// checkPermissions returns an error if the group or other users can access the file.
+func checkPermissions(filename string, info os.FileInfo) error {
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("%s can be accessed by other users, run 'chmod 600 %s' or log in again", filename, filename)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package securefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	fileMode = 0600
+	dirMode  = 0700
+)
+
+// ReadFile reads a file, refusing to read it if other users can access it.
+func ReadFile(filename string) ([]byte, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPermissions(filename, info)
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadFile(filename)
+}
+
+// WriteFile writes a file which only the current user can access.
+//
+// The data is written to a temporary file which is renamed over the original,
+// so readers never see a partially written file.
+func WriteFile(filename string, data []byte) error {
+	dir := path.Dir(filename)
+
+	err := os.MkdirAll(dir, dirMode)
+	if err != nil {
+		return errors.Wrap(err, "Failed to create directory")
+	}
+
+	err = os.Chmod(dir, dirMode)
+	if err != nil {
+		return errors.Wrap(err, "Failed to set directory permissions")
+	}
+
+	// TempFile creates the file with 0600 permissions.
+	file, err := ioutil.TempFile(dir, path.Base(filename)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "Failed to create temporary file")
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return errors.Wrap(err, "Failed to write temporary file")
+	}
+
+	err = file.Sync()
+	if err != nil {
+		file.Close()
+		return errors.Wrap(err, "Failed to sync temporary file")
+	}
+
+	err = file.Close()
+	if err != nil {
+		return errors.Wrap(err, "Failed to close temporary file")
+	}
+
+	err = os.Rename(file.Name(), filename)
+	if err != nil {
+		return errors.Wrap(err, "Failed to rename temporary file")
+	}
+
+	return nil
+}
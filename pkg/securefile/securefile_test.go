@@ -0,0 +1,57 @@
+//go:build !windows
+// +build !windows
+
+package securefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "securefile")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "cache", "test.yml")
+	assert.Nil(t, WriteFile(filename, []byte("first")))
+	assert.Nil(t, WriteFile(filename, []byte("second")))
+
+	info, err := os.Stat(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Dir(filename))
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	// Only the file itself is left behind.
+	files, err := ioutil.ReadDir(filepath.Dir(filename))
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	data, err := ReadFile(filename)
+	assert.Nil(t, err)
+	assert.Equal(t, "second", string(data))
+}
+
+func TestReadFileRefusesSharedFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "securefile")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "test.yml")
+	assert.Nil(t, ioutil.WriteFile(filename, []byte("data"), 0600))
+	assert.Nil(t, os.Chmod(filename, 0644))
+
+	_, err = ReadFile(filename)
+	assert.Error(t, err)
+
+	assert.Nil(t, os.Chmod(filename, 0600))
+	_, err = ReadFile(filename)
+	assert.Nil(t, err)
+}
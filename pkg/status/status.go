@@ -0,0 +1,133 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+	"github.com/skpr/cognito-auth/pkg/output"
+)
+
+// Exit codes, so scripts can check the status without parsing the output.
+const (
+	// ExitLoggedIn means the AWS credentials are valid.
+	ExitLoggedIn = 0
+	// ExitLoggedOut means there is no session, so a login is required.
+	ExitLoggedOut = 2
+	// ExitExpired means the session can be refreshed, but the AWS credentials have expired.
+	ExitExpired = 3
+)
+
+// Status of the cached session.
+type Status struct {
+	CacheBackend       string     `json:"cache_backend"`
+	LoggedIn           bool       `json:"logged_in"`
+	HasRefreshToken    bool       `json:"has_refresh_token"`
+	TokensExpiry       *time.Time `json:"tokens_expiry"`
+	TokensExpired      bool       `json:"tokens_expired"`
+	CredentialsExpiry  *time.Time `json:"credentials_expiry"`
+	CredentialsExpired bool       `json:"credentials_expired"`
+	IdentityID         string     `json:"identity_id,omitempty"`
+	Username           string     `json:"username,omitempty"`
+	Email              string     `json:"email,omitempty"`
+}
+
+// Check reads the status from the caches, without refreshing anything.
+func Check(cacheBackend string, tokenCache oauth.TokenCache, credentialsCache awscreds.CredentialsCache) Status {
+	status := Status{
+		CacheBackend:       cacheBackend,
+		TokensExpired:      true,
+		CredentialsExpired: true,
+	}
+
+	tokens, err := tokenCache.Get()
+	if err == nil {
+		status.HasRefreshToken = tokens.RefreshToken != ""
+		status.TokensExpiry = &tokens.Expiry
+		status.TokensExpired = tokens.HasExpired(0)
+		if claims, err := oauth.ParseClaims(tokens.IDToken); err == nil {
+			status.Username = claims.Username
+			status.Email = claims.Email
+		}
+	}
+
+	credentials, err := credentialsCache.Get()
+	if err == nil {
+		status.CredentialsExpiry = &credentials.Expiry
+		status.CredentialsExpired = credentials.HasExpired(0)
+		status.IdentityID = credentials.IdentityID
+	}
+
+	status.LoggedIn = status.HasRefreshToken || !status.CredentialsExpired
+
+	return status
+}
+
+// ExitCode gets the exit code for the status.
+func (s Status) ExitCode() int {
+	if !s.LoggedIn {
+		return ExitLoggedOut
+	}
+	if s.CredentialsExpired {
+		return ExitExpired
+	}
+	return ExitLoggedIn
+}
+
+// Print out the status in the given format.
+func (s Status) Print(w io.Writer, format string) error {
+	switch format {
+	case output.FormatJSON:
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal status")
+		}
+		fmt.Fprintln(w, string(data))
+	case output.FormatText:
+		table := uitable.New()
+		table.MaxColWidth = 80
+		table.AddRow("Cache:", s.CacheBackend)
+		table.AddRow("Logged in:", yesNo(s.LoggedIn))
+		table.AddRow("Refresh token:", yesNo(s.HasRefreshToken))
+		table.AddRow("Tokens expire:", formatExpiry(s.TokensExpiry))
+		table.AddRow("Credentials expire:", formatExpiry(s.CredentialsExpiry))
+		if s.IdentityID != "" {
+			table.AddRow("Identity ID:", s.IdentityID)
+		}
+		if s.Username != "" {
+			table.AddRow("Username:", s.Username)
+		}
+		if s.Email != "" {
+			table.AddRow("Email:", s.Email)
+		}
+		fmt.Fprintln(w, table)
+	default:
+		return errors.Errorf("unsupported output format: %s", format)
+	}
+	return nil
+}
+
+// formatExpiry formats an expiry time, noting if it has passed.
+func formatExpiry(expiry *time.Time) string {
+	if expiry == nil {
+		return "-"
+	}
+	if expiry.Before(time.Now()) {
+		return expiry.Local().Format(time.RFC1123) + " (expired)"
+	}
+	return expiry.Local().Format(time.RFC1123)
+}
+
+// yesNo formats a boolean for display.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,69 @@
+package status
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+type tokenCache struct {
+	tokens oauth.Tokens
+	err    error
+}
+
+func (c tokenCache) Get() (oauth.Tokens, error) {
+	return c.tokens, c.err
+}
+
+func (c tokenCache) Put(tokens oauth.Tokens) error {
+	return nil
+}
+
+func (c tokenCache) Delete(tokens oauth.Tokens) error {
+	return nil
+}
+
+type credentialsCache struct {
+	credentials awscreds.Credentials
+	err         error
+}
+
+func (c credentialsCache) Get() (awscreds.Credentials, error) {
+	return c.credentials, c.err
+}
+
+func (c credentialsCache) Put(credentials awscreds.Credentials) error {
+	return nil
+}
+
+func (c credentialsCache) Delete(credentials awscreds.Credentials) error {
+	return nil
+}
+
+func TestCheck(t *testing.T) {
+	notFound := errors.New("not found")
+	past := time.Now().Add(-300 * time.Second)
+	future := time.Now().Add(300 * time.Second)
+
+	status := Check("file", tokenCache{err: notFound}, credentialsCache{err: notFound})
+	assert.False(t, status.LoggedIn)
+	assert.Equal(t, ExitLoggedOut, status.ExitCode())
+
+	status = Check("file", tokenCache{tokens: oauth.Tokens{RefreshToken: "ABCDEFGHIJKLMNOP", Expiry: past}}, credentialsCache{credentials: awscreds.Credentials{Expiry: past}})
+	assert.True(t, status.LoggedIn)
+	assert.True(t, status.HasRefreshToken)
+	assert.True(t, status.CredentialsExpired)
+	assert.Equal(t, ExitExpired, status.ExitCode())
+
+	status = Check("file", tokenCache{tokens: oauth.Tokens{RefreshToken: "ABCDEFGHIJKLMNOP", Expiry: future}}, credentialsCache{credentials: awscreds.Credentials{Expiry: future, IdentityID: "ap-southeast-2:0123"}})
+	assert.True(t, status.LoggedIn)
+	assert.False(t, status.TokensExpired)
+	assert.False(t, status.CredentialsExpired)
+	assert.Equal(t, "ap-southeast-2:0123", status.IdentityID)
+	assert.Equal(t, ExitLoggedIn, status.ExitCode())
+}
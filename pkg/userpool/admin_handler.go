@@ -0,0 +1,192 @@
+package userpool
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/config"
+)
+
+// AdminHandler handles user pool administration.
+//
+// The identity provider must be created with the administrators own
+// credentials, as the Admin* APIs can't be called anonymously.
+type AdminHandler struct {
+	cognitoConfig    config.Config
+	identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
+}
+
+// NewAdminHandler creates a new admin handler.
+func NewAdminHandler(cognitoConfig *config.Config, identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *AdminHandler {
+	return &AdminHandler{
+		cognitoConfig:    *cognitoConfig,
+		identityProvider: identityProvider,
+	}
+}
+
+// CreateUser creates a new user, optionally without sending the welcome message.
+func (r *AdminHandler) CreateUser(username string, temporaryPassword string, attributes map[string]string, suppressMessage bool) (User, error) {
+	input := &cognitoidentityprovider.AdminCreateUserInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+		Username:   &username,
+	}
+	if temporaryPassword != "" {
+		input.SetTemporaryPassword(temporaryPassword)
+	}
+	if suppressMessage {
+		input.SetMessageAction(cognitoidentityprovider.MessageActionTypeSuppress)
+	}
+	for name, value := range attributes {
+		input.UserAttributes = append(input.UserAttributes, &cognitoidentityprovider.AttributeType{
+			Name:  aws.String(name),
+			Value: aws.String(value),
+		})
+	}
+
+	output, err := r.identityProvider.AdminCreateUser(input)
+	if err != nil {
+		return User{}, errors.Wrap(err, "Failed to create user")
+	}
+
+	return extractUser(output.User), nil
+}
+
+// DisableUser disables a user.
+func (r *AdminHandler) DisableUser(username string) error {
+	_, err := r.identityProvider.AdminDisableUser(&cognitoidentityprovider.AdminDisableUserInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+		Username:   &username,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to disable user")
+	}
+	return nil
+}
+
+// EnableUser enables a user.
+func (r *AdminHandler) EnableUser(username string) error {
+	_, err := r.identityProvider.AdminEnableUser(&cognitoidentityprovider.AdminEnableUserInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+		Username:   &username,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to enable user")
+	}
+	return nil
+}
+
+// ResetUserPassword resets a users password, sending them a reset code.
+func (r *AdminHandler) ResetUserPassword(username string) error {
+	_, err := r.identityProvider.AdminResetUserPassword(&cognitoidentityprovider.AdminResetUserPasswordInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+		Username:   &username,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to reset user password")
+	}
+	return nil
+}
+
+// SetUserPassword sets a users password, either permanently or as a temporary password.
+func (r *AdminHandler) SetUserPassword(username string, password string, permanent bool) error {
+	_, err := r.identityProvider.AdminSetUserPassword(&cognitoidentityprovider.AdminSetUserPasswordInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+		Username:   &username,
+		Password:   &password,
+		Permanent:  &permanent,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to set user password")
+	}
+	return nil
+}
+
+// AddUserToGroup adds a user to a group.
+func (r *AdminHandler) AddUserToGroup(username string, group string) error {
+	_, err := r.identityProvider.AdminAddUserToGroup(&cognitoidentityprovider.AdminAddUserToGroupInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+		Username:   &username,
+		GroupName:  &group,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to add user to group")
+	}
+	return nil
+}
+
+// ListUsers lists the users, optionally matching a filter such as `email ^= "jsmith"`.
+func (r *AdminHandler) ListUsers(filter string) ([]User, error) {
+	var users []User
+
+	input := &cognitoidentityprovider.ListUsersInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+	}
+	if filter != "" {
+		input.SetFilter(filter)
+	}
+	for {
+		output, err := r.identityProvider.ListUsers(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to list users")
+		}
+		for _, user := range output.Users {
+			users = append(users, extractUser(user))
+		}
+		if output.PaginationToken == nil {
+			break
+		}
+		input.PaginationToken = output.PaginationToken
+	}
+
+	return users, nil
+}
+
+// GlobalSignOut signs a user out of all devices, invalidating their refresh tokens.
+func (r *AdminHandler) GlobalSignOut(username string) error {
+	_, err := r.identityProvider.AdminUserGlobalSignOut(&cognitoidentityprovider.AdminUserGlobalSignOutInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+		Username:   &username,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to sign out user")
+	}
+	return nil
+}
+
+// GetPasswordPolicy gets the user pool password policy.
+func (r *AdminHandler) GetPasswordPolicy() (config.PasswordPolicy, error) {
+	output, err := r.identityProvider.DescribeUserPool(&cognitoidentityprovider.DescribeUserPoolInput{
+		UserPoolId: &r.cognitoConfig.UserPoolID,
+	})
+	if err != nil {
+		return config.PasswordPolicy{}, errors.Wrap(err, "Failed to describe user pool")
+	}
+
+	if output.UserPool.Policies == nil || output.UserPool.Policies.PasswordPolicy == nil {
+		return config.PasswordPolicy{}, nil
+	}
+
+	policy := output.UserPool.Policies.PasswordPolicy
+	return config.PasswordPolicy{
+		MinimumLength:    int(aws.Int64Value(policy.MinimumLength)),
+		RequireLowercase: aws.BoolValue(policy.RequireLowercase),
+		RequireUppercase: aws.BoolValue(policy.RequireUppercase),
+		RequireNumbers:   aws.BoolValue(policy.RequireNumbers),
+		RequireSymbols:   aws.BoolValue(policy.RequireSymbols),
+	}, nil
+}
+
+// extractUser extracts a user from the user type.
+func extractUser(userType *cognitoidentityprovider.UserType) User {
+	user := User{
+		Username:   aws.StringValue(userType.Username),
+		Status:     aws.StringValue(userType.UserStatus),
+		Enabled:    aws.BoolValue(userType.Enabled),
+		Attributes: map[string]string{},
+	}
+	for _, attribute := range userType.Attributes {
+		user.Attributes[aws.StringValue(attribute.Name)] = aws.StringValue(attribute.Value)
+	}
+	return user
+}
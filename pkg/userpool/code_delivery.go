@@ -0,0 +1,23 @@
+package userpool
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+)
+
+// CodeDelivery struct
+type CodeDelivery struct {
+	Destination    string
+	DeliveryMedium string
+}
+
+// extractCodeDelivery extracts where a confirmation code was sent.
+func extractCodeDelivery(details *cognitoidentityprovider.CodeDeliveryDetailsType) CodeDelivery {
+	if details == nil {
+		return CodeDelivery{}
+	}
+	return CodeDelivery{
+		Destination:    aws.StringValue(details.Destination),
+		DeliveryMedium: aws.StringValue(details.DeliveryMedium),
+	}
+}
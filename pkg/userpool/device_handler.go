@@ -0,0 +1,125 @@
+package userpool
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+// Device struct
+type Device struct {
+	Key               string
+	Name              string
+	LastIP            string
+	RememberedStatus  string
+	Created           time.Time
+	LastAuthenticated time.Time
+}
+
+// DeviceHandler handles the logged in users devices.
+type DeviceHandler struct {
+	tokensResolver   *oauth.TokensResolver
+	identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
+}
+
+// NewDeviceHandler creates a new device handler.
+func NewDeviceHandler(tokensResolver *oauth.TokensResolver, identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *DeviceHandler {
+	return &DeviceHandler{
+		tokensResolver:   tokensResolver,
+		identityProvider: identityProvider,
+	}
+}
+
+// ListDevices lists the devices of the logged in user.
+func (r *DeviceHandler) ListDevices() ([]Device, error) {
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get tokens")
+	}
+
+	var devices []Device
+	input := &cognitoidentityprovider.ListDevicesInput{
+		AccessToken: &tokens.AccessToken,
+	}
+	for {
+		output, err := r.identityProvider.ListDevices(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to list devices")
+		}
+		for _, device := range output.Devices {
+			devices = append(devices, extractDevice(device))
+		}
+		if output.PaginationToken == nil {
+			break
+		}
+		input.PaginationToken = output.PaginationToken
+	}
+
+	return devices, nil
+}
+
+// ForgetDevice forgets a device, so it must go through MFA again.
+func (r *DeviceHandler) ForgetDevice(deviceKey string) error {
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get tokens")
+	}
+
+	_, err = r.identityProvider.ForgetDevice(&cognitoidentityprovider.ForgetDeviceInput{
+		AccessToken: &tokens.AccessToken,
+		DeviceKey:   &deviceKey,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to forget device")
+	}
+
+	return nil
+}
+
+// RememberDevice updates whether a device is remembered.
+func (r *DeviceHandler) RememberDevice(deviceKey string, remembered bool) error {
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get tokens")
+	}
+
+	status := cognitoidentityprovider.DeviceRememberedStatusTypeRemembered
+	if !remembered {
+		status = cognitoidentityprovider.DeviceRememberedStatusTypeNotRemembered
+	}
+
+	_, err = r.identityProvider.UpdateDeviceStatus(&cognitoidentityprovider.UpdateDeviceStatusInput{
+		AccessToken:            &tokens.AccessToken,
+		DeviceKey:              &deviceKey,
+		DeviceRememberedStatus: &status,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to update device status")
+	}
+
+	return nil
+}
+
+// extractDevice extracts a device from the device type.
+func extractDevice(device *cognitoidentityprovider.DeviceType) Device {
+	d := Device{
+		Key:               aws.StringValue(device.DeviceKey),
+		Created:           aws.TimeValue(device.DeviceCreateDate),
+		LastAuthenticated: aws.TimeValue(device.DeviceLastAuthenticatedDate),
+	}
+	for _, attribute := range device.DeviceAttributes {
+		switch aws.StringValue(attribute.Name) {
+		case "device_name":
+			d.Name = aws.StringValue(attribute.Value)
+		case "last_ip_used":
+			d.LastIP = aws.StringValue(attribute.Value)
+		case "dev:device_remembered_status":
+			d.RememberedStatus = aws.StringValue(attribute.Value)
+		}
+	}
+	return d
+}
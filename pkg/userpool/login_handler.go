@@ -2,27 +2,29 @@ package userpool
 
 import (
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
 	"github.com/pkg/errors"
 	"github.com/skpr/cognito-auth/pkg/awscreds"
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/oauth"
+	"github.com/skpr/cognito-auth/pkg/password"
 )
 
 // LoginHandler handles cognito user pool functions.
 type LoginHandler struct {
 	tokenCache              oauth.TokenCache
 	cognitoConfig           config.Config
-	cognitoIdentityProvider cognitoidentityprovider.CognitoIdentityProvider
-	credentialsResolver     awscreds.CredentialsResolver
+	cognitoIdentityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
+	credentialsResolver     *awscreds.CredentialsResolver
 }
 
 // NewLoginHandler creates a new login handler.
-func NewLoginHandler(tokenCache oauth.TokenCache, cognitoConfig *config.Config, cognitoIdentityProvider *cognitoidentityprovider.CognitoIdentityProvider, credentialsResolver *awscreds.CredentialsResolver) LoginHandler {
+func NewLoginHandler(tokenCache oauth.TokenCache, cognitoConfig *config.Config, cognitoIdentityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI, credentialsResolver *awscreds.CredentialsResolver) LoginHandler {
 	return LoginHandler{
 		tokenCache:              tokenCache,
 		cognitoConfig:           *cognitoConfig,
-		cognitoIdentityProvider: *cognitoIdentityProvider,
-		credentialsResolver:     *credentialsResolver,
+		cognitoIdentityProvider: cognitoIdentityProvider,
+		credentialsResolver:     credentialsResolver,
 	}
 }
 
@@ -33,10 +35,15 @@ func (r *LoginHandler) Login(username string, password string) (awscreds.Credent
 	authInput.SetAuthFlow(cognitoidentityprovider.AuthFlowTypeUserPasswordAuth)
 	authInput.SetClientId(r.cognitoConfig.ClientID)
 
-	authInput.SetAuthParameters(map[string]*string{
+	authParameters := map[string]*string{
 		"USERNAME": &username,
 		"PASSWORD": &password,
-	})
+	}
+	if r.cognitoConfig.ClientSecret != "" {
+		hash := secretHash(username, r.cognitoConfig.ClientID, r.cognitoConfig.ClientSecret)
+		authParameters["SECRET_HASH"] = &hash
+	}
+	authInput.SetAuthParameters(authParameters)
 	authOutput, err := r.cognitoIdentityProvider.InitiateAuth(authInput)
 	if err != nil {
 		return awscreds.Credentials{}, ChallengeResponse{}, errors.Wrap(err, "Failed to login to identity provider")
@@ -68,12 +75,21 @@ func (r *LoginHandler) Login(username string, password string) (awscreds.Credent
 }
 
 // ChangePasswordChallenge responds to a change password challenge.
-func (r *LoginHandler) ChangePasswordChallenge(username string, password string, sess string) (awscreds.Credentials, error) {
+func (r *LoginHandler) ChangePasswordChallenge(username string, newPassword string, sess string) (awscreds.Credentials, error) {
+
+	err := password.Validate(r.cognitoConfig.PasswordPolicy, newPassword)
+	if err != nil {
+		return awscreds.Credentials{}, err
+	}
 
 	challengeName := "NEW_PASSWORD_REQUIRED"
 	challengeResponses := map[string]*string{
 		"USERNAME":     &username,
-		"NEW_PASSWORD": &password,
+		"NEW_PASSWORD": &newPassword,
+	}
+	if r.cognitoConfig.ClientSecret != "" {
+		hash := secretHash(username, r.cognitoConfig.ClientID, r.cognitoConfig.ClientSecret)
+		challengeResponses["SECRET_HASH"] = &hash
 	}
 
 	input := cognitoidentityprovider.RespondToAuthChallengeInput{
@@ -88,6 +104,12 @@ func (r *LoginHandler) ChangePasswordChallenge(username string, password string,
 	}
 
 	tokens := extractTokensFromAuthResult(output.AuthenticationResult)
+
+	err = r.tokenCache.Put(tokens)
+	if err != nil {
+		return awscreds.Credentials{}, errors.Wrap(err, "Failed to save tokens to cache")
+	}
+
 	credentials, err := r.credentialsResolver.GetTempCredentials(tokens.IDToken)
 	if err != nil {
 		return awscreds.Credentials{}, errors.Wrap(err, "Failed to login to identity provider")
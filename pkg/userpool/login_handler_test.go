@@ -0,0 +1,158 @@
+package userpool
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+func TestLogin(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientSecret  string
+		output        *cognitoidentityprovider.InitiateAuthOutput
+		err           error
+		identityErr   error
+		wantChallenge ChallengeResponse
+		wantErr       string
+	}{
+		{
+			name:   "authenticated",
+			output: &cognitoidentityprovider.InitiateAuthOutput{AuthenticationResult: authResult()},
+		},
+		{
+			name:         "authenticated with client secret",
+			clientSecret: "ASDFGHKL",
+			output:       &cognitoidentityprovider.InitiateAuthOutput{AuthenticationResult: authResult()},
+		},
+		{
+			name: "challenged",
+			output: &cognitoidentityprovider.InitiateAuthOutput{
+				ChallengeName: aws.String("NEW_PASSWORD_REQUIRED"),
+				Session:       aws.String("SESSION1234"),
+			},
+			wantChallenge: ChallengeResponse{Name: "NEW_PASSWORD_REQUIRED", Session: "SESSION1234"},
+		},
+		{
+			name:    "incorrect password",
+			err:     errors.New("NotAuthorizedException"),
+			wantErr: "Failed to login to identity provider: NotAuthorizedException",
+		},
+		{
+			name:        "no identity",
+			output:      &cognitoidentityprovider.InitiateAuthOutput{AuthenticationResult: authResult()},
+			identityErr: errors.New("ResourceNotFoundException"),
+			wantErr:     "Failed to get temporary credentials: Failed to get cognito user id: ResourceNotFoundException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{ClientID: "ABCDEFGHIJK", ClientSecret: tt.clientSecret}
+			tokenCache := oauth.NewMemoryCache(false)
+			credentialsCache := awscreds.NewMemoryCache(false)
+			identityProvider := &fakeIdentityProvider{initiateAuthOutput: tt.output, initiateAuthErr: tt.err}
+			resolver := newCredentialsResolver(cognitoConfig, tokenCache, credentialsCache, identityProvider, &fakeIdentity{err: tt.identityErr})
+
+			handler := NewLoginHandler(tokenCache, cognitoConfig, identityProvider, resolver)
+			creds, challenge, err := handler.Login("jsmith", "password")
+
+			params := identityProvider.initiateAuthInput.AuthParameters
+			assert.Equal(t, "jsmith", *params["USERNAME"])
+			assert.Equal(t, "password", *params["PASSWORD"])
+			if tt.clientSecret != "" {
+				assert.Equal(t, secretHash("jsmith", "ABCDEFGHIJK", tt.clientSecret), *params["SECRET_HASH"])
+			} else {
+				assert.NotContains(t, params, "SECRET_HASH")
+			}
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantChallenge, challenge)
+
+			if tt.wantChallenge.Name != "" {
+				_, err = tokenCache.Get()
+				assert.Error(t, err, "tokens aren't cached when challenged")
+				return
+			}
+
+			assert.Equal(t, "ABCDEFGHIJKLMNOP", creds.AccessKey)
+			tokens, err := tokenCache.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, "ABCDEFGHIJKLMNOP", tokens.RefreshToken)
+			assert.Equal(t, oauth.SessionUserPool, tokens.Session)
+			cached, err := credentialsCache.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, "ap-southeast-2:0123", cached.IdentityID)
+		})
+	}
+}
+
+func TestChangePasswordChallenge(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		policy   *config.PasswordPolicy
+		err      error
+		wantErr  string
+	}{
+		{
+			name:     "changed",
+			password: "Correct-Horse-7",
+		},
+		{
+			name:     "rejected by policy",
+			password: "short",
+			policy:   &config.PasswordPolicy{MinimumLength: 12},
+			wantErr:  "Password does not meet the password policy:\n  - must be at least 12 characters",
+		},
+		{
+			name:     "rejected by user pool",
+			password: "Correct-Horse-7",
+			err:      errors.New("InvalidPasswordException"),
+			wantErr:  "Password challenge failed: InvalidPasswordException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{ClientID: "ABCDEFGHIJK", PasswordPolicy: tt.policy}
+			tokenCache := oauth.NewMemoryCache(false)
+			identityProvider := &fakeIdentityProvider{
+				respondToAuthChallengeOutput: &cognitoidentityprovider.RespondToAuthChallengeOutput{AuthenticationResult: authResult()},
+				respondToAuthChallengeErr:    tt.err,
+			}
+			resolver := newCredentialsResolver(cognitoConfig, tokenCache, awscreds.NewMemoryCache(false), identityProvider, &fakeIdentity{})
+
+			handler := NewLoginHandler(tokenCache, cognitoConfig, identityProvider, resolver)
+			creds, err := handler.ChangePasswordChallenge("jsmith", tt.password, "SESSION1234")
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				_, err = tokenCache.Get()
+				assert.Error(t, err, "tokens aren't cached when the challenge fails")
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, "ABCDEFGHIJKLMNOP", creds.AccessKey)
+
+			input := identityProvider.respondToAuthChallengeInput
+			assert.Equal(t, "NEW_PASSWORD_REQUIRED", *input.ChallengeName)
+			assert.Equal(t, "SESSION1234", *input.Session)
+			assert.Equal(t, tt.password, *input.ChallengeResponses["NEW_PASSWORD"])
+
+			_, err = tokenCache.Get()
+			assert.Nil(t, err)
+		})
+	}
+}
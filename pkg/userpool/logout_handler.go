@@ -2,6 +2,7 @@ package userpool
 
 import (
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
 	"github.com/pkg/errors"
 	"github.com/skpr/cognito-auth/pkg/awscreds"
 	"github.com/skpr/cognito-auth/pkg/oauth"
@@ -10,19 +11,19 @@ import (
 // LogoutHandler struct.
 type LogoutHandler struct {
 	credentialsCache        awscreds.CredentialsCache
-	credentialsResolver     awscreds.CredentialsResolver
+	credentialsResolver     *awscreds.CredentialsResolver
 	tokenCache              oauth.TokenCache
-	tokensResolver          oauth.TokensResolver
-	cognitoIdentityProvider cognitoidentityprovider.CognitoIdentityProvider
+	tokensResolver          *oauth.TokensResolver
+	cognitoIdentityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
 }
 
 // NewLogoutHandler creates a logout handler.
-func NewLogoutHandler(credentialsCache awscreds.CredentialsCache, tokenCache oauth.TokenCache, tokensResolver *oauth.TokensResolver, cognitoIdentityProvider *cognitoidentityprovider.CognitoIdentityProvider) *LogoutHandler {
+func NewLogoutHandler(credentialsCache awscreds.CredentialsCache, tokenCache oauth.TokenCache, tokensResolver *oauth.TokensResolver, cognitoIdentityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *LogoutHandler {
 	return &LogoutHandler{
 		credentialsCache:        credentialsCache,
 		tokenCache:              tokenCache,
-		cognitoIdentityProvider: *cognitoIdentityProvider,
-		tokensResolver:          *tokensResolver,
+		cognitoIdentityProvider: cognitoIdentityProvider,
+		tokensResolver:          tokensResolver,
 	}
 }
 
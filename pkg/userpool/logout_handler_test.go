@@ -0,0 +1,76 @@
+package userpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/awscreds"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/lock"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+func TestLogout(t *testing.T) {
+	tests := []struct {
+		name       string
+		loggedIn   bool
+		err        error
+		wantErr    string
+		wantCached bool
+	}{
+		{
+			name:     "logged out",
+			loggedIn: true,
+		},
+		{
+			name:    "not logged in",
+			wantErr: "Failed to get tokens from cache: not found: tokens",
+		},
+		{
+			name:       "sign out failed",
+			loggedIn:   true,
+			err:        errors.New("NotAuthorizedException"),
+			wantErr:    "Failed to sign out: NotAuthorizedException",
+			wantCached: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{ClientID: "ABCDEFGHIJK"}
+			tokenCache := oauth.NewMemoryCache(false)
+			credentialsCache := awscreds.NewMemoryCache(false)
+			if tt.loggedIn {
+				assert.Nil(t, tokenCache.Put(oauth.Tokens{
+					AccessToken:  "ABCDEFGHIJKLMNOP1234567890",
+					RefreshToken: "ABCDEFGHIJKLMNOP",
+					IDToken:      idToken,
+					Expiry:       time.Now().Add(time.Hour),
+				}))
+				assert.Nil(t, credentialsCache.Put(awscreds.Credentials{Expiry: time.Now().Add(time.Hour)}))
+			}
+
+			identityProvider := &fakeIdentityProvider{globalSignOutErr: tt.err}
+			tokensRefresher := NewTokensRefresher(cognitoConfig, tokenCache, identityProvider)
+			tokensResolver := oauth.NewTokensResolver(tokenCache, tokensRefresher, cognitoConfig.ExpiryMargin(), lock.Nop{})
+
+			handler := NewLogoutHandler(credentialsCache, tokenCache, tokensResolver, identityProvider)
+			err := handler.Logout()
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "ABCDEFGHIJKLMNOP1234567890", *identityProvider.globalSignOutInput.AccessToken)
+			}
+
+			_, tokensErr := tokenCache.Get()
+			_, credentialsErr := credentialsCache.Get()
+			assert.Equal(t, tt.wantCached, tokensErr == nil, "tokens cached")
+			assert.Equal(t, tt.wantCached, credentialsErr == nil, "credentials cached")
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package userpool
+
+import (
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+// PasswordChanger type
+type PasswordChanger struct {
+	tokensResolver   *oauth.TokensResolver
+	identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
+}
+
+// NewPasswordChanger creates a new password changer.
+func NewPasswordChanger(tokensResolver *oauth.TokensResolver, identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *PasswordChanger {
+	return &PasswordChanger{
+		tokensResolver:   tokensResolver,
+		identityProvider: identityProvider,
+	}
+}
+
+// ChangePassword changes the password of the logged in user.
+func (r *PasswordChanger) ChangePassword(previousPassword string, proposedPassword string) error {
+
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get tokens")
+	}
+
+	input := &cognitoidentityprovider.ChangePasswordInput{
+		AccessToken:      &tokens.AccessToken,
+		PreviousPassword: &previousPassword,
+		ProposedPassword: &proposedPassword,
+	}
+
+	_, err = r.identityProvider.ChangePassword(input)
+	if err != nil {
+		return errors.Wrap(err, "Failed to change password.")
+	}
+
+	return nil
+}
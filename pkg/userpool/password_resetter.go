@@ -2,21 +2,23 @@ package userpool
 
 import (
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
 	"github.com/pkg/errors"
 	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/password"
 )
 
 // PasswordResetter type
 type PasswordResetter struct {
 	CognitoConfig    config.Config
-	identityProvider cognitoidentityprovider.CognitoIdentityProvider
+	identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
 }
 
 // NewPasswordResetter creates a new password resetter.
-func NewPasswordResetter(cognitoConfig *config.Config, identityProvider *cognitoidentityprovider.CognitoIdentityProvider) *PasswordResetter {
+func NewPasswordResetter(cognitoConfig *config.Config, identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *PasswordResetter {
 	return &PasswordResetter{
 		CognitoConfig:    *cognitoConfig,
-		identityProvider: *identityProvider,
+		identityProvider: identityProvider,
 	}
 }
 
@@ -27,6 +29,9 @@ func (r *PasswordResetter) InitResetPassword(username string) error {
 		ClientId: &r.CognitoConfig.ClientID,
 		Username: &username,
 	}
+	if r.CognitoConfig.ClientSecret != "" {
+		forgotPasswordInput.SetSecretHash(secretHash(username, r.CognitoConfig.ClientID, r.CognitoConfig.ClientSecret))
+	}
 
 	_, err := r.identityProvider.ForgotPassword(forgotPasswordInput)
 
@@ -39,16 +44,24 @@ func (r *PasswordResetter) InitResetPassword(username string) error {
 }
 
 // ConfirmResetPassword confirms the password reset.
-func (r *PasswordResetter) ConfirmResetPassword(username string, password string, code string) error {
+func (r *PasswordResetter) ConfirmResetPassword(username string, newPassword string, code string) error {
+
+	err := password.Validate(r.CognitoConfig.PasswordPolicy, newPassword)
+	if err != nil {
+		return err
+	}
 
 	input := &cognitoidentityprovider.ConfirmForgotPasswordInput{
 		ClientId:         &r.CognitoConfig.ClientID,
 		Username:         &username,
-		Password:         &password,
+		Password:         &newPassword,
 		ConfirmationCode: &code,
 	}
+	if r.CognitoConfig.ClientSecret != "" {
+		input.SetSecretHash(secretHash(username, r.CognitoConfig.ClientID, r.CognitoConfig.ClientSecret))
+	}
 
-	_, err := r.identityProvider.ConfirmForgotPassword(input)
+	_, err = r.identityProvider.ConfirmForgotPassword(input)
 
 	if err != nil {
 		return errors.Wrap(err, "Failed to confirm password reset.")
@@ -0,0 +1,126 @@
+package userpool
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+// User struct
+type User struct {
+	Username   string
+	Status     string
+	Enabled    bool
+	Attributes map[string]string
+}
+
+// ProfileHandler handles the logged in users profile.
+type ProfileHandler struct {
+	tokensResolver   *oauth.TokensResolver
+	identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
+}
+
+// NewProfileHandler creates a new profile handler.
+func NewProfileHandler(tokensResolver *oauth.TokensResolver, identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *ProfileHandler {
+	return &ProfileHandler{
+		tokensResolver:   tokensResolver,
+		identityProvider: identityProvider,
+	}
+}
+
+// GetUser gets the logged in user and their attributes.
+func (r *ProfileHandler) GetUser() (User, error) {
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return User{}, errors.Wrap(err, "Failed to get tokens")
+	}
+
+	output, err := r.identityProvider.GetUser(&cognitoidentityprovider.GetUserInput{
+		AccessToken: &tokens.AccessToken,
+	})
+	if err != nil {
+		return User{}, errors.Wrap(err, "Failed to get user")
+	}
+
+	user := User{
+		Username:   aws.StringValue(output.Username),
+		Attributes: map[string]string{},
+	}
+	for _, attribute := range output.UserAttributes {
+		user.Attributes[aws.StringValue(attribute.Name)] = aws.StringValue(attribute.Value)
+	}
+
+	return user, nil
+}
+
+// UpdateAttributes updates the logged in users attributes.
+//
+// Changed attributes which require verification (e.g. email or phone_number)
+// are returned with where their verification code was sent.
+func (r *ProfileHandler) UpdateAttributes(attributes map[string]string) (map[string]CodeDelivery, error) {
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to get tokens")
+	}
+
+	input := &cognitoidentityprovider.UpdateUserAttributesInput{
+		AccessToken: &tokens.AccessToken,
+	}
+	for name, value := range attributes {
+		input.UserAttributes = append(input.UserAttributes, &cognitoidentityprovider.AttributeType{
+			Name:  aws.String(name),
+			Value: aws.String(value),
+		})
+	}
+
+	output, err := r.identityProvider.UpdateUserAttributes(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to update user attributes")
+	}
+
+	deliveries := map[string]CodeDelivery{}
+	for _, details := range output.CodeDeliveryDetailsList {
+		deliveries[aws.StringValue(details.AttributeName)] = extractCodeDelivery(details)
+	}
+
+	return deliveries, nil
+}
+
+// GetAttributeVerificationCode sends a verification code for an attribute.
+func (r *ProfileHandler) GetAttributeVerificationCode(name string) (CodeDelivery, error) {
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return CodeDelivery{}, errors.Wrap(err, "Failed to get tokens")
+	}
+
+	output, err := r.identityProvider.GetUserAttributeVerificationCode(&cognitoidentityprovider.GetUserAttributeVerificationCodeInput{
+		AccessToken:   &tokens.AccessToken,
+		AttributeName: &name,
+	})
+	if err != nil {
+		return CodeDelivery{}, errors.Wrap(err, "Failed to get attribute verification code")
+	}
+
+	return extractCodeDelivery(output.CodeDeliveryDetails), nil
+}
+
+// VerifyAttribute verifies an attribute with the code that was sent.
+func (r *ProfileHandler) VerifyAttribute(name string, code string) error {
+	tokens, err := r.tokensResolver.GetTokens()
+	if err != nil {
+		return errors.Wrap(err, "Failed to get tokens")
+	}
+
+	_, err = r.identityProvider.VerifyUserAttribute(&cognitoidentityprovider.VerifyUserAttributeInput{
+		AccessToken:   &tokens.AccessToken,
+		AttributeName: &name,
+		Code:          &code,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Failed to verify attribute")
+	}
+
+	return nil
+}
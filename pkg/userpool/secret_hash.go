@@ -0,0 +1,14 @@
+package userpool
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// secretHash computes the SECRET_HASH required by app clients with a client secret.
+func secretHash(username string, clientID string, clientSecret string) string {
+	mac := hmac.New(sha256.New, []byte(clientSecret))
+	mac.Write([]byte(username + clientID))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,11 @@
+package userpool
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSecretHash(t *testing.T) {
+	hash := secretHash("jsmith", "ABCDEFGHIJK", "ASDFGHKL")
+	assert.Equal(t, "IUaYEOc1Ya+rV7JPE5TKXQYCeQC2nrA4uEmaUxWkJtA=", hash, "secret hash was computed")
+}
@@ -0,0 +1,94 @@
+package userpool
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
+	"github.com/pkg/errors"
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/password"
+)
+
+// SignupHandler type
+type SignupHandler struct {
+	cognitoConfig    config.Config
+	identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
+}
+
+// NewSignupHandler creates a new signup handler.
+func NewSignupHandler(cognitoConfig *config.Config, identityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *SignupHandler {
+	return &SignupHandler{
+		cognitoConfig:    *cognitoConfig,
+		identityProvider: identityProvider,
+	}
+}
+
+// SignUp registers a new user, returning whether the user is already confirmed.
+func (r *SignupHandler) SignUp(username string, newPassword string, attributes map[string]string) (bool, CodeDelivery, error) {
+
+	err := password.Validate(r.cognitoConfig.PasswordPolicy, newPassword)
+	if err != nil {
+		return false, CodeDelivery{}, err
+	}
+
+	input := &cognitoidentityprovider.SignUpInput{
+		ClientId: &r.cognitoConfig.ClientID,
+		Username: &username,
+		Password: &newPassword,
+	}
+	for name, value := range attributes {
+		input.UserAttributes = append(input.UserAttributes, &cognitoidentityprovider.AttributeType{
+			Name:  aws.String(name),
+			Value: aws.String(value),
+		})
+	}
+	if r.cognitoConfig.ClientSecret != "" {
+		input.SetSecretHash(secretHash(username, r.cognitoConfig.ClientID, r.cognitoConfig.ClientSecret))
+	}
+
+	output, err := r.identityProvider.SignUp(input)
+	if err != nil {
+		return false, CodeDelivery{}, errors.Wrap(err, "Failed to sign up.")
+	}
+
+	return aws.BoolValue(output.UserConfirmed), extractCodeDelivery(output.CodeDeliveryDetails), nil
+}
+
+// ConfirmSignUp confirms a new user with the code they were sent.
+func (r *SignupHandler) ConfirmSignUp(username string, code string) error {
+
+	input := &cognitoidentityprovider.ConfirmSignUpInput{
+		ClientId:         &r.cognitoConfig.ClientID,
+		Username:         &username,
+		ConfirmationCode: &code,
+	}
+	if r.cognitoConfig.ClientSecret != "" {
+		input.SetSecretHash(secretHash(username, r.cognitoConfig.ClientID, r.cognitoConfig.ClientSecret))
+	}
+
+	_, err := r.identityProvider.ConfirmSignUp(input)
+	if err != nil {
+		return errors.Wrap(err, "Failed to confirm sign up.")
+	}
+
+	return nil
+}
+
+// ResendConfirmationCode sends a new confirmation code to the user.
+func (r *SignupHandler) ResendConfirmationCode(username string) (CodeDelivery, error) {
+
+	input := &cognitoidentityprovider.ResendConfirmationCodeInput{
+		ClientId: &r.cognitoConfig.ClientID,
+		Username: &username,
+	}
+	if r.cognitoConfig.ClientSecret != "" {
+		input.SetSecretHash(secretHash(username, r.cognitoConfig.ClientID, r.cognitoConfig.ClientSecret))
+	}
+
+	output, err := r.identityProvider.ResendConfirmationCode(input)
+	if err != nil {
+		return CodeDelivery{}, errors.Wrap(err, "Failed to resend confirmation code.")
+	}
+
+	return extractCodeDelivery(output.CodeDeliveryDetails), nil
+}
@@ -0,0 +1,185 @@
+package userpool
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/config"
+)
+
+func TestSignUp(t *testing.T) {
+	codeDelivery := &cognitoidentityprovider.CodeDeliveryDetailsType{
+		Destination:    aws.String("j***@e***.com"),
+		DeliveryMedium: aws.String("EMAIL"),
+	}
+
+	tests := []struct {
+		name           string
+		clientSecret   string
+		passwordPolicy *config.PasswordPolicy
+		password       string
+		attributes     map[string]string
+		output         *cognitoidentityprovider.SignUpOutput
+		err            error
+		wantConfirmed  bool
+		wantDelivery   CodeDelivery
+		wantAttributes map[string]string
+		wantErr        string
+	}{
+		{
+			name:           "code sent",
+			password:       "correct-horse-battery-42",
+			attributes:     map[string]string{"email": "jsmith@example.com", "name": "John Smith"},
+			output:         &cognitoidentityprovider.SignUpOutput{UserConfirmed: aws.Bool(false), CodeDeliveryDetails: codeDelivery},
+			wantDelivery:   CodeDelivery{Destination: "j***@e***.com", DeliveryMedium: "EMAIL"},
+			wantAttributes: map[string]string{"email": "jsmith@example.com", "name": "John Smith"},
+		},
+		{
+			name:           "confirmed with client secret",
+			clientSecret:   "ASDFGHKL",
+			password:       "correct-horse-battery-42",
+			output:         &cognitoidentityprovider.SignUpOutput{UserConfirmed: aws.Bool(true)},
+			wantConfirmed:  true,
+			wantAttributes: map[string]string{},
+		},
+		{
+			name:           "password policy",
+			passwordPolicy: &config.PasswordPolicy{MinimumLength: 12},
+			password:       "Sh0rtPass!",
+			wantErr:        "Password does not meet the password policy:\n  - must be at least 12 characters",
+		},
+		{
+			name:     "username exists",
+			password: "correct-horse-battery-42",
+			err:      errors.New("UsernameExistsException"),
+			wantErr:  "Failed to sign up.: UsernameExistsException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{ClientID: "ABCDEFGHIJK", ClientSecret: tt.clientSecret, PasswordPolicy: tt.passwordPolicy}
+			identityProvider := &fakeIdentityProvider{signUpOutput: tt.output, signUpErr: tt.err}
+
+			handler := NewSignupHandler(cognitoConfig, identityProvider)
+			confirmed, delivery, err := handler.SignUp("jsmith", tt.password, tt.attributes)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantConfirmed, confirmed)
+			assert.Equal(t, tt.wantDelivery, delivery)
+
+			input := identityProvider.signUpInput
+			assert.Equal(t, "ABCDEFGHIJK", aws.StringValue(input.ClientId))
+			assert.Equal(t, "jsmith", aws.StringValue(input.Username))
+			assert.Equal(t, tt.password, aws.StringValue(input.Password))
+			attributes := map[string]string{}
+			for _, attribute := range input.UserAttributes {
+				attributes[aws.StringValue(attribute.Name)] = aws.StringValue(attribute.Value)
+			}
+			assert.Equal(t, tt.wantAttributes, attributes)
+			if tt.clientSecret != "" {
+				assert.Equal(t, secretHash("jsmith", "ABCDEFGHIJK", tt.clientSecret), aws.StringValue(input.SecretHash))
+			} else {
+				assert.Nil(t, input.SecretHash)
+			}
+		})
+	}
+}
+
+func TestConfirmSignUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientSecret string
+		err          error
+		wantErr      string
+	}{
+		{name: "confirmed"},
+		{name: "confirmed with client secret", clientSecret: "ASDFGHKL"},
+		{name: "incorrect code", err: errors.New("CodeMismatchException"), wantErr: "Failed to confirm sign up.: CodeMismatchException"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{ClientID: "ABCDEFGHIJK", ClientSecret: tt.clientSecret}
+			identityProvider := &fakeIdentityProvider{confirmSignUpErr: tt.err}
+
+			err := NewSignupHandler(cognitoConfig, identityProvider).ConfirmSignUp("jsmith", "123456")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+
+			input := identityProvider.confirmSignUpInput
+			assert.Equal(t, "jsmith", aws.StringValue(input.Username))
+			assert.Equal(t, "123456", aws.StringValue(input.ConfirmationCode))
+			if tt.clientSecret != "" {
+				assert.Equal(t, secretHash("jsmith", "ABCDEFGHIJK", tt.clientSecret), aws.StringValue(input.SecretHash))
+			} else {
+				assert.Nil(t, input.SecretHash)
+			}
+		})
+	}
+}
+
+func TestResendConfirmationCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientSecret string
+		output       *cognitoidentityprovider.ResendConfirmationCodeOutput
+		err          error
+		wantDelivery CodeDelivery
+		wantErr      string
+	}{
+		{
+			name: "code sent",
+			output: &cognitoidentityprovider.ResendConfirmationCodeOutput{
+				CodeDeliveryDetails: &cognitoidentityprovider.CodeDeliveryDetailsType{
+					Destination:    aws.String("+******1234"),
+					DeliveryMedium: aws.String("SMS"),
+				},
+			},
+			wantDelivery: CodeDelivery{Destination: "+******1234", DeliveryMedium: "SMS"},
+		},
+		{
+			name:         "code sent with client secret",
+			clientSecret: "ASDFGHKL",
+			output:       &cognitoidentityprovider.ResendConfirmationCodeOutput{},
+		},
+		{
+			name:    "throttled",
+			err:     errors.New("LimitExceededException"),
+			wantErr: "Failed to resend confirmation code.: LimitExceededException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{ClientID: "ABCDEFGHIJK", ClientSecret: tt.clientSecret}
+			identityProvider := &fakeIdentityProvider{resendConfirmationCodeOutput: tt.output, resendConfirmationCodeErr: tt.err}
+
+			delivery, err := NewSignupHandler(cognitoConfig, identityProvider).ResendConfirmationCode("jsmith")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantDelivery, delivery)
+
+			input := identityProvider.resendConfirmationCodeInput
+			assert.Equal(t, "jsmith", aws.StringValue(input.Username))
+			if tt.clientSecret != "" {
+				assert.Equal(t, secretHash("jsmith", "ABCDEFGHIJK", tt.clientSecret), aws.StringValue(input.SecretHash))
+			} else {
+				assert.Nil(t, input.SecretHash)
+			}
+		})
+	}
+}
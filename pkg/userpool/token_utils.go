@@ -9,11 +9,13 @@ import (
 // extractTokensFromAuthResult extracts oauth tokens from the authentication result.
 func extractTokensFromAuthResult(authResult *cognitoidentityprovider.AuthenticationResultType) oauth.Tokens {
 	ttl := time.Duration(*authResult.ExpiresIn * int64(time.Second))
-	expiry := time.Now().Add(ttl).Truncate(time.Duration(time.Second))
+	issued := time.Now().Truncate(time.Duration(time.Second))
 	tokens := oauth.Tokens{
 		AccessToken: *authResult.AccessToken,
-		Expiry:      expiry,
+		Expiry:      issued.Add(ttl),
+		Issued:      issued,
 		IDToken:     *authResult.IdToken,
+		Session:     oauth.SessionUserPool,
 	}
 	if authResult.RefreshToken != nil {
 		tokens.RefreshToken = *authResult.RefreshToken
@@ -2,6 +2,7 @@ package userpool
 
 import (
 	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider/cognitoidentityprovideriface"
 	"github.com/pkg/errors"
 	"github.com/skpr/cognito-auth/pkg/config"
 	"github.com/skpr/cognito-auth/pkg/oauth"
@@ -11,15 +12,15 @@ import (
 type TokensRefresher struct {
 	cognitoConfig           config.Config
 	tokenCache              oauth.TokenCache
-	cognitoIdentityProvider cognitoidentityprovider.CognitoIdentityProvider
+	cognitoIdentityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI
 }
 
 // NewTokensRefresher creates a new tokens refresher.
-func NewTokensRefresher(cognitoConfig *config.Config, tokenCache oauth.TokenCache, cognitoIdentityProvider *cognitoidentityprovider.CognitoIdentityProvider) *TokensRefresher {
+func NewTokensRefresher(cognitoConfig *config.Config, tokenCache oauth.TokenCache, cognitoIdentityProvider cognitoidentityprovideriface.CognitoIdentityProviderAPI) *TokensRefresher {
 	return &TokensRefresher{
 		cognitoConfig:           *cognitoConfig,
 		tokenCache:              tokenCache,
-		cognitoIdentityProvider: *cognitoIdentityProvider,
+		cognitoIdentityProvider: cognitoIdentityProvider,
 	}
 }
 
@@ -29,9 +30,18 @@ func (r *TokensRefresher) RefreshOAuthTokens(refreshToken string) (oauth.Tokens,
 	authInput := new(cognitoidentityprovider.InitiateAuthInput)
 	authInput.SetAuthFlow(cognitoidentityprovider.AuthFlowTypeRefreshTokenAuth)
 	authInput.SetClientId(r.cognitoConfig.ClientID)
-	authInput.SetAuthParameters(map[string]*string{
+	authParameters := map[string]*string{
 		cognitoidentityprovider.AuthFlowTypeRefreshToken: &refreshToken,
-	})
+	}
+	if r.cognitoConfig.ClientSecret != "" {
+		username, err := r.getUsername()
+		if err != nil {
+			return oauth.Tokens{}, errors.Wrap(err, "Failed to compute secret hash")
+		}
+		hash := secretHash(username, r.cognitoConfig.ClientID, r.cognitoConfig.ClientSecret)
+		authParameters["SECRET_HASH"] = &hash
+	}
+	authInput.SetAuthParameters(authParameters)
 	authOutput, err := r.cognitoIdentityProvider.InitiateAuth(authInput)
 	if err != nil {
 		return oauth.Tokens{}, errors.Wrap(err, "Failed to refresh oauth2 tokens")
@@ -48,3 +58,19 @@ func (r *TokensRefresher) RefreshOAuthTokens(refreshToken string) (oauth.Tokens,
 
 	return tokens, nil
 }
+
+// getUsername gets the username of the cached tokens, which is needed to compute the secret hash.
+func (r *TokensRefresher) getUsername() (string, error) {
+	tokens, err := r.tokenCache.Get()
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to get tokens from cache")
+	}
+	claims, err := oauth.ParseClaims(tokens.IDToken)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to parse id_token")
+	}
+	if claims.Username != "" {
+		return claims.Username, nil
+	}
+	return claims.Subject, nil
+}
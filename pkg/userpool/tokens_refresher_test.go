@@ -0,0 +1,83 @@
+package userpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/cognitoidentityprovider"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/skpr/cognito-auth/pkg/config"
+	"github.com/skpr/cognito-auth/pkg/oauth"
+)
+
+func TestRefreshOAuthTokens(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientSecret string
+		cached       bool
+		err          error
+		wantErr      string
+	}{
+		{
+			name: "refreshed",
+		},
+		{
+			name:         "refreshed with client secret",
+			clientSecret: "ASDFGHKL",
+			cached:       true,
+		},
+		{
+			name:         "client secret without cached username",
+			clientSecret: "ASDFGHKL",
+			wantErr:      "Failed to compute secret hash: Failed to get tokens from cache: not found: tokens",
+		},
+		{
+			name:    "refresh token revoked",
+			err:     errors.New("NotAuthorizedException"),
+			wantErr: "Failed to refresh oauth2 tokens: NotAuthorizedException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cognitoConfig := &config.Config{ClientID: "ABCDEFGHIJK", ClientSecret: tt.clientSecret}
+			tokenCache := oauth.NewMemoryCache(false)
+			if tt.cached {
+				assert.Nil(t, tokenCache.Put(oauth.Tokens{IDToken: idToken, Expiry: time.Now().Add(-time.Minute)}))
+			}
+
+			// The refresh response doesn't include a refresh token.
+			result := authResult()
+			result.RefreshToken = nil
+			identityProvider := &fakeIdentityProvider{
+				initiateAuthOutput: &cognitoidentityprovider.InitiateAuthOutput{AuthenticationResult: result},
+				initiateAuthErr:    tt.err,
+			}
+
+			refresher := NewTokensRefresher(cognitoConfig, tokenCache, identityProvider)
+			tokens, err := refresher.RefreshOAuthTokens("REFRESH1234")
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.Nil(t, err)
+
+			input := identityProvider.initiateAuthInput
+			assert.Equal(t, cognitoidentityprovider.AuthFlowTypeRefreshTokenAuth, *input.AuthFlow)
+			assert.Equal(t, "REFRESH1234", *input.AuthParameters[cognitoidentityprovider.AuthFlowTypeRefreshToken])
+			if tt.clientSecret != "" {
+				assert.Equal(t, secretHash("jsmith", "ABCDEFGHIJK", tt.clientSecret), *input.AuthParameters["SECRET_HASH"])
+			}
+
+			assert.Equal(t, "REFRESH1234", tokens.RefreshToken, "refresh token was kept")
+			assert.False(t, tokens.HasExpired(0))
+
+			cached, err := tokenCache.Get()
+			assert.Nil(t, err)
+			assert.Equal(t, tokens, cached)
+		})
+	}
+}